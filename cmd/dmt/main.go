@@ -1,17 +1,34 @@
 package main
 
 import (
+	"cmp"
+	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
 
 	"github.com/fatih/color"
 	"github.com/mitchellh/go-homedir"
+	"sigs.k8s.io/yaml"
 
+	"github.com/deckhouse/dmt/internal/baseline"
+	"github.com/deckhouse/dmt/internal/cache"
+	"github.com/deckhouse/dmt/internal/changes"
 	"github.com/deckhouse/dmt/internal/flags"
+	"github.com/deckhouse/dmt/internal/generators"
 	"github.com/deckhouse/dmt/internal/logger"
 	"github.com/deckhouse/dmt/internal/manager"
+	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/dmt"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/formatters"
 )
 
 var version = "HEAD"
@@ -28,6 +45,15 @@ func main() {
 	gen := flags.InitGenFlagSet()
 	gen.AddFlagSet(defaults)
 
+	configFlags := flags.InitConfigFlagSet()
+	configFlags.AddFlagSet(defaults)
+
+	renderFlags := flags.InitRenderFlagSet()
+	renderFlags.AddFlagSet(defaults)
+
+	cacheFlags := flags.InitCacheFlagSet()
+	cacheFlags.AddFlagSet(defaults)
+
 	if len(os.Args) < 2 {
 		flags.GeneralParse(defaults)
 		defaults.Usage()
@@ -38,51 +64,395 @@ func main() {
 	case "lint":
 		flags.GeneralParse(lint)
 
-		var dirs = lint.Args()[1:]
-		if len(dirs) == 0 {
-			dirs = []string{"."}
-		}
-
-		if len(dirs) == 0 {
+		if flags.Watch {
+			runWatch(parseDirs(lint.Args()[1:]))
 			return
 		}
-
-		var parsedDirs []string
-		for _, dir := range dirs {
-			d, err := homedir.Expand(dir)
-			if err != nil {
-				logger.ErrorF("Error expanding directory: %v", err)
-				continue
-			}
-			d, err = filepath.Abs(d)
-			if err != nil {
-				logger.ErrorF("Error expanding directory: %v\n", err)
-				continue
-			}
-			parsedDirs = append(parsedDirs, d)
-		}
-		runLint(parsedDirs)
+		runLint(parseDirs(lint.Args()[1:]))
+	case "render":
+		flags.GeneralParse(renderFlags)
+		runRender(renderFlags.Args()[1:], renderFlags.Usage)
 	case "gen":
 		flags.GeneralParse(gen)
+		runGen(gen.Args()[1:], gen.Usage)
+	case "config":
+		flags.GeneralParse(configFlags)
+		runConfig(configFlags.Args()[1:], configFlags.Usage)
+	case "cache":
+		flags.GeneralParse(cacheFlags)
+		runCache(cacheFlags.Args()[1:], cacheFlags.Usage)
 	default:
 		flags.GeneralParse(defaults)
 		defaults.Usage()
 	}
 }
 
+func parseDirs(dirs []string) []string {
+	if len(dirs) == 0 {
+		dirs = []string{"."}
+	}
+
+	var parsedDirs []string
+	for _, dir := range dirs {
+		d, err := homedir.Expand(dir)
+		if err != nil {
+			logger.ErrorF("Error expanding directory: %v", err)
+			continue
+		}
+		d, err = filepath.Abs(d)
+		if err != nil {
+			logger.ErrorF("Error expanding directory: %v\n", err)
+			continue
+		}
+		parsedDirs = append(parsedDirs, d)
+	}
+
+	return parsedDirs
+}
+
 func runLint(dirs []string) {
 	logger.InfoF("Dirs: %v", dirs)
 
-	cfg, err := config.NewDefault(dirs)
+	logger.CheckErr(checkLintFlags())
+
+	formatter, err := formatters.New(flags.Format)
+	logger.CheckErr(err)
+
+	failOn, err := errors.ParseSeverity(flags.FailOn)
+	logger.CheckErr(err)
+
+	cfg, err := loadLintConfig(dirs)
 	logger.CheckErr(err)
 
-	mng := manager.NewManager(dirs, cfg)
-	result := mng.Run()
-	if result.ConvertToError() != nil {
-		fmt.Printf("%s\n", result.ConvertToError())
+	// the first interrupt stops the run and prints findings of finished linters, the second one terminates dmt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	context.AfterFunc(ctx, stop)
+
+	opts := dmt.Options{Parallel: flags.LintersLimit, Timeout: flags.LinterTimeout, ValuesFiles: flags.ValuesFiles}
+
+	var changed *changes.Changes
+	if flags.ChangedSince != "" {
+		changed, err = changes.Load(ctx, dirs, flags.ChangedSince)
+		logger.CheckErr(err)
+		opts.ChangedFiles = changed.Files()
+		logger.InfoF("%d files are changed since %s", len(opts.ChangedFiles), flags.ChangedSince)
+	}
+
+	// exclusions which suppressed findings of cached modules are not recorded, so unused exclusions need a full run
+	if !flags.NoCache && !flags.ReportUnusedExclusions && !flags.RemoveUnusedExclusions {
+		opts.CacheDir, err = cacheDir()
+		if err != nil {
+			logger.WarnF("The cache is disabled: %s", err)
+		}
+	}
+
+	run, err := dmt.Lint(ctx, cfg, dirs, opts)
+	if run == nil {
+		logger.CheckErr(err)
+	}
+	result := run.Findings
+	reportTimings(run.Timings)
+
+	if err != nil {
+		logger.WarnF("The run is interrupted, only findings of finished linters are reported")
+		logger.CheckErr(formatter.Format(os.Stdout, newReport(run, &result)))
+		os.Exit(1)
+	}
+
+	if flags.ReportUnusedExclusions || flags.RemoveUnusedExclusions {
+		reportUnusedExclusions(cfg)
+	}
+	if flags.ExpiringWithin > 0 {
+		reportExpiringExclusions(cfg)
+	}
+
+	if flags.WriteBaseline != "" {
+		b := baseline.New(&result)
+		logger.CheckErr(b.Save(flags.WriteBaseline))
+		logger.InfoF("%d findings are written to the baseline %s", len(b.Entries), flags.WriteBaseline)
+
+		logger.CheckErr(formatter.Format(os.Stdout, newReport(run, &result)))
+
+		return
+	}
+
+	if flags.Baseline != "" {
+		result = applyBaseline(run.Modules, &result)
 	}
 
-	if result.Critical() {
+	if flags.ChangedLines {
+		result = applyChangedLines(run.Modules, &result, changed)
+	}
+
+	err = formatter.Format(os.Stdout, newReport(run, &result))
+	logger.CheckErr(err)
+
+	if result.HasSeverity(failOn) {
 		os.Exit(1)
 	}
 }
+
+// checkLintFlags returns an error if lint flags can't be used together.
+func checkLintFlags() error {
+	if flags.ChangedLines && flags.ChangedSince == "" {
+		return fmt.Errorf("--changed-lines requires --changed-since")
+	}
+	// exclusions of modules which are not linted would be reported and removed as unused
+	if flags.ChangedSince != "" && (flags.ReportUnusedExclusions || flags.RemoveUnusedExclusions) {
+		return fmt.Errorf("--changed-since can't be used with --report-unused-exclusions and --remove-unused-exclusions")
+	}
+
+	return nil
+}
+
+// loadLintConfig loads the config of the linted directories with linters selected by flags.
+func loadLintConfig(dirs []string) (*config.Config, error) {
+	return config.NewDefault(dirs, config.LoaderOptions{
+		Config:         flags.ConfigPath,
+		Enable:         flags.Enable,
+		Disable:        flags.Disable,
+		AllowRulePacks: flags.AllowRulePacks,
+	})
+}
+
+// reportTimings logs durations of phases of the run, durations of modules and linters are logged in the debug level.
+func reportTimings(timings dmt.Timings) {
+	logger.InfoF("Timings: discovery %s, loading %s, linting %s",
+		timings.Discovery.Round(time.Millisecond), timings.Loading.Round(time.Millisecond), timings.Linting.Round(time.Millisecond))
+
+	for _, name := range slices.Sorted(maps.Keys(timings.Modules)) {
+		logger.DebugF("Module `%s` is loaded in %s", name, timings.Modules[name].Round(time.Millisecond))
+	}
+	for _, name := range slices.Sorted(maps.Keys(timings.Linters)) {
+		logger.DebugF("Linter `%s` ran for %s on all modules", name, timings.Linters[name].Round(time.Millisecond))
+	}
+}
+
+// applyBaseline drops findings recorded in the baseline and reports baseline entries which no longer occur.
+func applyBaseline(modules []dmt.Module, result *errors.LintRuleErrorsList) errors.LintRuleErrorsList {
+	b, err := baseline.Load(flags.Baseline)
+	logger.CheckErr(err)
+
+	names := make([]string, 0, len(modules))
+	for _, m := range modules {
+		names = append(names, m.Name)
+	}
+
+	filtered, stale := b.Filter(result, names)
+	logger.InfoF("%d findings are hidden by the baseline %s", result.Len()-filtered.Len(), flags.Baseline)
+
+	for _, entry := range stale {
+		logger.WarnF("Baseline entry no longer occurs: %s", entry)
+	}
+	if len(stale) > 0 {
+		logger.WarnF("%d baseline entries no longer occur, run `dmt lint --write-baseline %s` to remove them", len(stale), flags.Baseline)
+	}
+
+	return *filtered
+}
+
+// applyChangedLines drops findings outside of changed lines of changed files.
+func applyChangedLines(modules []dmt.Module, result *errors.LintRuleErrorsList, changed *changes.Changes) errors.LintRuleErrorsList {
+	paths := make(map[string]string, len(modules))
+	for _, m := range modules {
+		paths[m.Name] = m.Path
+	}
+
+	filtered := changed.Filter(result, paths)
+	logger.InfoF("%d findings outside of changed lines are hidden", result.Len()-filtered.Len())
+
+	return *filtered
+}
+
+// reportUnusedExclusions warns about exclusions in config files which did not match anything during the run
+// and removes them from config files if it is requested.
+func reportUnusedExclusions(cfg *config.Config) {
+	unused, err := cfg.UnusedExclusions()
+	logger.CheckErr(err)
+
+	for _, file := range slices.Sorted(maps.Keys(unused)) {
+		for _, e := range unused[file] {
+			logger.WarnF("Exclusion is not used: %s: %s", file, e)
+		}
+
+		if flags.RemoveUnusedExclusions {
+			logger.CheckErr(config.RemoveExclusions(file, unused[file]))
+			logger.InfoF("%d unused exclusions are removed from %s", len(unused[file]), file)
+		}
+	}
+
+	if len(unused) > 0 && !flags.RemoveUnusedExclusions {
+		logger.WarnF("Run `dmt lint --remove-unused-exclusions` to remove unused exclusions from config files")
+	}
+}
+
+// reportExpiringExclusions lists exclusions in config files which expire soon.
+func reportExpiringExclusions(cfg *config.Config) {
+	expiring := cfg.ExpiringExclusions(flags.ExpiringWithin)
+	if len(expiring) == 0 {
+		return
+	}
+
+	logger.WarnF("%d exclusions expire by %s:", len(expiring), time.Now().Add(flags.ExpiringWithin).Format(time.DateOnly))
+	for _, e := range expiring {
+		details := cmp.Or(e.Issue, e.Reason)
+		if details != "" {
+			details = " (" + details + ")"
+		}
+		logger.WarnF("  %s: %s until %s%s", e.File, e.Exclusion, e.Until.Format(time.DateOnly), details)
+	}
+}
+
+// cacheDir returns the cache directory from flags or the default one.
+func cacheDir() (string, error) {
+	if flags.CacheDir != "" {
+		return homedir.Expand(flags.CacheDir)
+	}
+
+	return cache.DefaultDir()
+}
+
+func runCache(args []string, usage func()) {
+	if len(args) != 1 {
+		usage()
+		os.Exit(1)
+	}
+
+	dir, err := cacheDir()
+	logger.CheckErr(err)
+	c := cache.New(dir)
+
+	switch args[0] {
+	case "clean":
+		logger.CheckErr(c.Clean())
+		logger.InfoF("The cache %s is cleaned", dir)
+	case "stats":
+		stats, err := c.Stats()
+		logger.CheckErr(err)
+
+		fmt.Printf("Directory: %s\n", dir)
+		fmt.Printf("Entries: %d\n", stats.Entries)
+		fmt.Printf("Size: %.1f MiB\n", float64(stats.Size)/(1<<20))
+		if stats.Entries > 0 {
+			fmt.Printf("Oldest entry: %s\n", stats.Oldest.Format(time.DateTime))
+			fmt.Printf("Newest entry: %s\n", stats.Newest.Format(time.DateTime))
+		}
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func runGen(args []string, usage func()) {
+	if len(args) == 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "module":
+		if len(args) != 2 {
+			usage()
+			os.Exit(1)
+		}
+
+		dir, err := homedir.Expand(flags.GenOutput)
+		logger.CheckErr(err)
+
+		modulePath, err := generators.Module(args[1], dir)
+		logger.CheckErr(err)
+
+		logger.InfoF("Module `%s` is created in %s", args[1], modulePath)
+		generators.UpdateModuleDependencies(modulePath)
+	case "vpa":
+		runGenForModules(parseDirs(args[1:]), generators.VPA)
+	case "pdb":
+		runGenForModules(parseDirs(args[1:]), generators.PDB)
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func runGenForModules(dirs []string, generate func(m *module.Module) ([]byte, error)) {
+	cfg, err := config.NewDefault(dirs, config.LoaderOptions{AllowRulePacks: flags.AllowRulePacks})
+	logger.CheckErr(err)
+
+	mng, err := manager.NewManager(dirs, cfg)
+	logger.CheckErr(err)
+	mng.LoadModules(context.Background())
+	for _, m := range mng.Modules {
+		content, err := generate(m)
+		logger.CheckErr(err)
+
+		if len(content) == 0 {
+			logger.InfoF("Nothing to generate for `%s` module", m.GetName())
+			continue
+		}
+
+		fmt.Printf("# Module: %s\n%s", m.GetName(), content)
+	}
+}
+
+func runConfig(args []string, usage func()) {
+	if len(args) == 0 {
+		usage()
+		os.Exit(1)
+	}
+
+	switch args[0] {
+	case "show":
+		runConfigShow(args[1:])
+	case "schema":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetEscapeHTML(false)
+		encoder.SetIndent("", "  ")
+		logger.CheckErr(encoder.Encode(config.Schema()))
+	default:
+		usage()
+		os.Exit(1)
+	}
+}
+
+func runConfigShow(args []string) {
+	dirs := parseDirs(args)
+	cfg, err := config.NewDefault(dirs, config.LoaderOptions{Config: flags.ConfigPath, AllowRulePacks: flags.AllowRulePacks})
+	logger.CheckErr(err)
+
+	mng, err := manager.NewManager(dirs, cfg)
+	logger.CheckErr(err)
+	mng.LoadModules(context.Background())
+	for _, m := range mng.Modules {
+		mdlCfg := mng.ModuleConfig(m)
+
+		settings, err := mdlCfg.AsMap()
+		logger.CheckErr(err)
+
+		content, err := yaml.Marshal(settings)
+		logger.CheckErr(err)
+
+		fmt.Printf("# Module: %s\n", m.GetName())
+		for _, file := range mdlCfg.Files() {
+			fmt.Printf("# Config file: %s\n", file)
+		}
+		fmt.Printf("%s---\n", content)
+	}
+}
+
+func newReport(run *dmt.Result, result *errors.LintRuleErrorsList) *formatters.Report {
+	report := &formatters.Report{
+		Version: version,
+		Errors:  result,
+	}
+
+	for _, linter := range run.Linters {
+		report.Linters = append(report.Linters, formatters.LinterInfo{Name: linter.Name, Desc: linter.Description, Rules: linter.Rules})
+	}
+
+	for _, m := range run.Modules {
+		report.Modules = append(report.Modules, formatters.ModuleInfo{Name: m.Name, Path: m.Path})
+	}
+
+	return report
+}
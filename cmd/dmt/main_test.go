@@ -0,0 +1,31 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/flags"
+)
+
+func TestCheckLintFlags(t *testing.T) {
+	defer func() {
+		flags.ChangedSince, flags.ChangedLines = "", false
+		flags.ReportUnusedExclusions, flags.RemoveUnusedExclusions = false, false
+	}()
+
+	require.NoError(t, checkLintFlags())
+
+	flags.ChangedLines = true
+	require.ErrorContains(t, checkLintFlags(), "--changed-lines requires --changed-since")
+
+	flags.ChangedSince = "main"
+	require.NoError(t, checkLintFlags())
+
+	// only changed modules are linted, so exclusions of other modules look unused
+	flags.RemoveUnusedExclusions = true
+	require.ErrorContains(t, checkLintFlags(), "--changed-since can't be used with")
+
+	flags.RemoveUnusedExclusions, flags.ReportUnusedExclusions = false, true
+	require.ErrorContains(t, checkLintFlags(), "--changed-since can't be used with")
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"cmp"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	"github.com/deckhouse/dmt/internal/flags"
+	"github.com/deckhouse/dmt/internal/logger"
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
+)
+
+// runRender prints values generated for the module and objects rendered with them, exactly as linters see them.
+func runRender(args []string, usage func()) {
+	if len(args) != 1 {
+		usage()
+		os.Exit(1)
+	}
+
+	dirs := parseDirs(args)
+	if len(dirs) == 0 {
+		os.Exit(1)
+	}
+
+	var overrides map[string]any
+	if len(flags.ValuesFiles) > 0 {
+		var err error
+		overrides, err = module.ReadValuesFiles(flags.ValuesFiles)
+		logger.CheckErr(err)
+	}
+
+	mdl, values, err := module.RenderModule(dirs[0], overrides)
+	logger.CheckErr(err)
+
+	// modules without OpenAPI schemas are rendered without values
+	raw, _ := values["Values"].(map[string]any)
+	if raw == nil {
+		raw = make(map[string]any)
+	}
+	valuesContent, err := yaml.Marshal(raw)
+	logger.CheckErr(err)
+
+	objects := make([]storage.StoreObject, 0, len(mdl.GetStorage()))
+	for _, object := range mdl.GetStorage() {
+		objects = append(objects, object)
+	}
+	slices.SortFunc(objects, func(a, b storage.StoreObject) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Document, b.Document))
+	})
+
+	if flags.RenderOutput != "" {
+		writeRendered(flags.RenderOutput, valuesContent, objects)
+		logger.InfoF("Values and %d objects of `%s` module are written to %s", len(objects), mdl.GetName(), flags.RenderOutput)
+		return
+	}
+
+	fmt.Printf("# Values of `%s` module\n%s", mdl.GetName(), valuesContent)
+	for _, object := range objects {
+		content, err := renderObject(object)
+		logger.CheckErr(err)
+		fmt.Printf("---\n%s", content)
+	}
+}
+
+// renderObject returns the YAML of the object with the comment about its template.
+func renderObject(object storage.StoreObject) ([]byte, error) {
+	content, err := yaml.Marshal(object.Unstructured.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	source := object.ShortPath()
+	if object.Line > 0 {
+		source = fmt.Sprintf("%s:%d", source, object.Line)
+	}
+
+	return append([]byte("# Source: "+source+"\n"), content...), nil
+}
+
+// writeRendered writes values to values.yaml and every object to its own file in the directory.
+func writeRendered(dir string, values []byte, objects []storage.StoreObject) {
+	logger.CheckErr(os.MkdirAll(dir, 0o755))
+	logger.CheckErr(os.WriteFile(filepath.Join(dir, "values.yaml"), values, 0o600))
+
+	replacer := strings.NewReplacer("/", "-", ":", "-", string(filepath.Separator), "-")
+	for _, object := range objects {
+		parts := []string{strings.ToLower(object.Unstructured.GetKind())}
+		if ns := object.Unstructured.GetNamespace(); ns != "" {
+			parts = append(parts, ns)
+		}
+		parts = append(parts, object.Unstructured.GetName())
+
+		content, err := renderObject(object)
+		logger.CheckErr(err)
+
+		name := replacer.Replace(strings.Join(parts, "_")) + ".yaml"
+		logger.CheckErr(os.WriteFile(filepath.Join(dir, name), content, 0o600))
+	}
+}
@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"slices"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+
+	"github.com/deckhouse/dmt/internal/flags"
+	"github.com/deckhouse/dmt/internal/logger"
+	"github.com/deckhouse/dmt/internal/watch"
+	"github.com/deckhouse/dmt/pkg/dmt"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/formatters"
+)
+
+// watchState contains findings of the last run of every module.
+type watchState struct {
+	// findings contains findings by module names, findings of config files are stored by the empty name.
+	findings map[string][]*errors.LintRuleError
+	// paths contains directories of linted modules by their names.
+	paths map[string]string
+}
+
+// update replaces findings of modules linted in the run and compares them with the previous ones.
+// Findings of modules which can't be loaded are kept, findings of removed modules are fixed.
+func (s *watchState) update(run *dmt.Result) (added, fixed, unchanged []*errors.LintRuleError) {
+	byModule := make(map[string][]*errors.LintRuleError)
+	for _, e := range run.Findings.GetErrors() {
+		byModule[e.Module] = append(byModule[e.Module], e)
+	}
+
+	affected := []string{""}
+	for _, m := range run.Modules {
+		affected = append(affected, m.Name)
+		s.paths[m.Name] = m.Path
+	}
+	for name, path := range s.paths {
+		if _, err := os.Stat(path); !slices.Contains(affected, name) && os.IsNotExist(err) {
+			affected = append(affected, name)
+			delete(s.paths, name)
+		}
+	}
+
+	for name, findings := range s.findings {
+		if !slices.Contains(affected, name) {
+			unchanged = append(unchanged, findings...)
+		}
+	}
+	for _, name := range affected {
+		a, f, u := watch.Diff(s.findings[name], byModule[name])
+		added = append(added, a...)
+		fixed = append(fixed, f...)
+		unchanged = append(unchanged, u...)
+
+		s.findings[name] = byModule[name]
+		if len(byModule[name]) == 0 {
+			delete(s.findings, name)
+		}
+	}
+
+	return added, fixed, unchanged
+}
+
+// runWatch lints modules and lints them again when their files change, findings which appeared and were fixed are printed.
+func runWatch(dirs []string) {
+	logger.InfoF("Dirs: %v", dirs)
+
+	if flags.Format != "text" {
+		logger.CheckErr(fmt.Errorf("--watch supports only the text format"))
+	}
+	if flags.ChangedSince != "" || flags.WriteBaseline != "" || flags.ReportUnusedExclusions || flags.RemoveUnusedExclusions {
+		logger.CheckErr(fmt.Errorf("--watch can't be used with --changed-since, --write-baseline and unused exclusions flags"))
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	opts := dmt.Options{Parallel: flags.LintersLimit, Timeout: flags.LinterTimeout, ValuesFiles: flags.ValuesFiles}
+	if !flags.NoCache {
+		var err error
+		if opts.CacheDir, err = cacheDir(); err != nil {
+			logger.WarnF("The cache is disabled: %s", err)
+		}
+	}
+
+	// configFiles are config files of the last run, their directories are watched as well
+	var configFiles []string
+	lint := func(changed []string) *dmt.Result {
+		cfg, err := loadLintConfig(dirs)
+		if err != nil {
+			logger.ErrorF("Cannot load the config: %s", err)
+			return nil
+		}
+		configFiles = cfg.Files()
+
+		opts.ChangedFiles = changed
+		run, err := dmt.Lint(ctx, cfg, dirs, opts)
+		if err != nil {
+			if ctx.Err() == nil {
+				logger.ErrorF("Cannot lint modules: %s", err)
+			}
+			return nil
+		}
+		if flags.Baseline != "" {
+			run.Findings = applyBaseline(run.Modules, &run.Findings)
+		}
+
+		return run
+	}
+
+	state := &watchState{findings: make(map[string][]*errors.LintRuleError), paths: make(map[string]string)}
+	if run := lint(nil); run != nil {
+		added, _, _ := state.update(run)
+		printFindings(added)
+	}
+
+	var configDirs []string
+	for _, file := range configFiles {
+		configDirs = append(configDirs, filepath.Dir(file))
+	}
+	w, err := watch.New(dirs, configDirs)
+	logger.CheckErr(err)
+	defer w.Close()
+
+	logger.InfoF("Watching for changes, press Ctrl-C to stop")
+	for {
+		changed, err := w.Next(ctx)
+		if err != nil {
+			return
+		}
+
+		run := lint(changed)
+		if run == nil {
+			continue
+		}
+
+		added, fixed, unchanged := state.update(run)
+		fmt.Printf("%s %d new, %d fixed, %d unchanged findings\n\n",
+			time.Now().Format(time.TimeOnly), len(added), len(fixed), len(unchanged))
+		if len(added) > 0 {
+			fmt.Println(color.New(color.Bold).Sprint("New findings:"))
+			printFindings(added)
+		}
+		if len(fixed) > 0 {
+			fmt.Println(color.New(color.Bold).Sprint("Fixed findings:"))
+			for _, e := range fixed {
+				fmt.Printf("%s [#%s] %s (module %s, object %s)\n", color.GreenString("✔"), e.ID, e.Text, e.Module, e.ObjectID)
+			}
+			fmt.Println()
+		}
+	}
+}
+
+// printFindings prints the findings in the text format.
+func printFindings(findings []*errors.LintRuleError) {
+	list := errors.LintRuleErrorsList{}
+	for _, e := range findings {
+		list.Add(e)
+	}
+
+	formatter := &formatters.TextFormatter{}
+	logger.CheckErr(formatter.Format(os.Stdout, &formatters.Report{Version: version, Errors: &list}))
+}
@@ -0,0 +1,147 @@
+// Package baseline records existing findings to a file, so that only new findings are reported in later runs.
+package baseline
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// Version is the version of the baseline file layout, it is increased on breaking changes.
+// Entries of version 1 files have no rule, they match findings of any rule.
+const Version = 2
+
+// versionWithoutRules is the version of baseline files with entries without the rule.
+const versionWithoutRules = 1
+
+const filePermissions = 0o644
+
+// Entry is the fingerprint of a finding, it doesn't contain the location,
+// so findings are matched even if the file is edited.
+type Entry struct {
+	Linter   string `json:"linter"`
+	Rule     string `json:"rule,omitempty"`
+	ID       string `json:"id"`
+	Module   string `json:"module"`
+	ObjectID string `json:"objectId"`
+	Text     string `json:"text"`
+}
+
+func newEntry(e *errors.LintRuleError) Entry {
+	return Entry{
+		Linter:   e.Linter,
+		Rule:     e.Rule,
+		ID:       e.ID,
+		Module:   e.Module,
+		ObjectID: e.ObjectID,
+		Text:     e.Text,
+	}
+}
+
+func (e Entry) String() string {
+	return fmt.Sprintf("[#%s] %s: %s (%s)", e.ID, e.Module, e.Text, e.ObjectID)
+}
+
+// Baseline is a set of accepted findings.
+type Baseline struct {
+	Version int     `json:"version"`
+	Entries []Entry `json:"findings"`
+}
+
+// New creates a baseline with all findings from the list.
+func New(list *errors.LintRuleErrorsList) *Baseline {
+	b := &Baseline{Version: Version}
+	seen := make(map[Entry]bool)
+	for _, e := range list.GetErrors() {
+		entry := newEntry(e)
+		if !seen[entry] {
+			seen[entry] = true
+			b.Entries = append(b.Entries, entry)
+		}
+	}
+
+	slices.SortFunc(b.Entries, func(a, b Entry) int {
+		return cmp.Or(
+			cmp.Compare(a.Module, b.Module),
+			cmp.Compare(a.Linter, b.Linter),
+			cmp.Compare(a.Rule, b.Rule),
+			cmp.Compare(a.ID, b.ID),
+			cmp.Compare(a.ObjectID, b.ObjectID),
+			cmp.Compare(a.Text, b.Text),
+		)
+	})
+
+	return b
+}
+
+// Load reads the baseline file.
+func Load(path string) (*Baseline, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read baseline: %w", err)
+	}
+
+	b := &Baseline{}
+	if err = json.Unmarshal(content, b); err != nil {
+		return nil, fmt.Errorf("parse baseline %s: %w", path, err)
+	}
+
+	if b.Version != Version && b.Version != versionWithoutRules {
+		return nil, fmt.Errorf("unsupported baseline version %d, expected %d", b.Version, Version)
+	}
+
+	return b, nil
+}
+
+// Save writes the baseline to the file.
+func (b *Baseline) Save(path string) error {
+	content, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	//nolint:gosec // the baseline is committed to the repository, it is not secret
+	if err = os.WriteFile(path, append(content, '\n'), filePermissions); err != nil {
+		return fmt.Errorf("write baseline: %w", err)
+	}
+
+	return nil
+}
+
+// Filter returns findings from the list which are not in the baseline and
+// baseline entries of the linted modules which no longer occur.
+func (b *Baseline) Filter(list *errors.LintRuleErrorsList, modules []string) (*errors.LintRuleErrorsList, []Entry) {
+	accepted := make(map[Entry]bool, len(b.Entries))
+	for _, entry := range b.Entries {
+		accepted[entry] = true
+	}
+
+	result := &errors.LintRuleErrorsList{}
+	found := make(map[Entry]bool)
+	for _, e := range list.GetErrors() {
+		entry := newEntry(e)
+		if !accepted[entry] {
+			// entries of old baselines have no rule
+			entry.Rule = ""
+		}
+		if accepted[entry] {
+			found[entry] = true
+			continue
+		}
+
+		result.Add(e)
+	}
+
+	var stale []Entry
+	for _, entry := range b.Entries {
+		if !found[entry] && slices.Contains(modules, entry.Module) {
+			stale = append(stale, entry)
+		}
+	}
+
+	return result, stale
+}
@@ -0,0 +1,72 @@
+package baseline
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func newFinding(module, objectID, text string) *errors.LintRuleError {
+	e := errors.NewLintRuleError("container", objectID, module, nil, text).WithRule("security-context").
+		WithFilePath("templates/app.yaml").WithPosition(1, 1)
+	e.Linter = "container"
+
+	return e
+}
+
+func TestBaseline(t *testing.T) {
+	list := &errors.LintRuleErrorsList{}
+	list.Add(newFinding("module-a", "kind = Deployment ; name = a", "Container SecurityContext is not defined"))
+	list.Add(newFinding("module-a", "kind = Deployment ; name = b", "Container SecurityContext is not defined"))
+	list.Add(newFinding("module-b", "kind = Deployment ; name = c", "Container SecurityContext is not defined"))
+
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, New(list).Save(path))
+
+	b, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, b.Entries, 3)
+
+	current := &errors.LintRuleErrorsList{}
+	// the position is not a part of the fingerprint
+	current.Add(newFinding("module-a", "kind = Deployment ; name = a", "Container SecurityContext is not defined").WithPosition(10, 1))
+	current.Add(newFinding("module-a", "kind = Deployment ; name = a", "Ephemeral storage for container is not defined"))
+
+	result, stale := b.Filter(current, []string{"module-a"})
+	require.Equal(t, 1, result.Len())
+	require.Equal(t, "Ephemeral storage for container is not defined", result.GetErrors()[0].Text)
+
+	// module-b is not linted, its entries are not stale
+	require.Len(t, stale, 1)
+	require.Equal(t, "kind = Deployment ; name = b", stale[0].ObjectID)
+}
+
+func TestBaseline_rules(t *testing.T) {
+	list := &errors.LintRuleErrorsList{}
+	list.Add(newFinding("module-a", "kind = Deployment ; name = a", "Container is invalid"))
+
+	current := &errors.LintRuleErrorsList{}
+	current.Add(newFinding("module-a", "kind = Deployment ; name = a", "Container is invalid"))
+	current.Add(newFinding("module-a", "kind = Deployment ; name = a", "Container is invalid").WithRule("ports"))
+
+	// the finding of the other rule with the same ID, object and text is new
+	result, stale := New(list).Filter(current, []string{"module-a"})
+	require.Equal(t, 1, result.Len())
+	require.Equal(t, "ports", result.GetErrors()[0].Rule)
+	require.Empty(t, stale)
+
+	// entries of version 1 baselines have no rule, they match findings of all rules
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version": 1, "findings": [{"linter": "container", "id": "container",
+		"module": "module-a", "objectId": "kind = Deployment ; name = a", "text": "Container is invalid"}]}`), 0o600))
+	b, err := Load(path)
+	require.NoError(t, err)
+
+	result, stale = b.Filter(current, []string{"module-a"})
+	require.Zero(t, result.Len())
+	require.Empty(t, stale)
+}
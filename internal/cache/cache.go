@@ -0,0 +1,156 @@
+// Package cache stores results of linting modules on disk, so unchanged modules are not rendered and linted again.
+//
+// An entry contains rendered objects and findings of a module, it is stored by a key which is a hash of dmt build,
+// the effective config of the module and contents of files the module depends on, so entries are never updated.
+package cache
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// modulesDir is the directory of entries of modules in the cache directory.
+const modulesDir = "modules"
+
+// entryExt is the extension of entry files, they are gzipped JSON files.
+const entryExt = ".json.gz"
+
+// Cache is a directory with entries of modules.
+type Cache struct {
+	dir string
+}
+
+// DefaultDir returns the "dmt" directory in the user cache directory, it is $XDG_CACHE_HOME/dmt on Linux.
+func DefaultDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(dir, "dmt"), nil
+}
+
+func New(dir string) *Cache {
+	return &Cache{dir: dir}
+}
+
+// Dir returns the cache directory.
+func (c *Cache) Dir() string {
+	return c.dir
+}
+
+func (c *Cache) path(key string) string {
+	return filepath.Join(c.dir, modulesDir, key[:2], key+entryExt)
+}
+
+// Get returns the entry of the key, it returns false if there is no entry or it can't be read.
+func (c *Cache) Get(key string) (*Entry, bool) {
+	f, err := os.Open(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	r, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, false
+	}
+
+	entry := new(Entry)
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	if err = decoder.Decode(entry); err != nil {
+		return nil, false
+	}
+
+	return entry, true
+}
+
+// Put stores the entry by the key, the file is replaced atomically so concurrent runs don't read partial entries.
+func (c *Cache) Put(key string, entry *Entry) error {
+	path := c.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), key+".*.tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	w := gzip.NewWriter(f)
+	if err = json.NewEncoder(w).Encode(entry); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = w.Close(); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err = f.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
+
+// Stats describes entries in the cache.
+type Stats struct {
+	Entries int
+	// Size is the total size of entry files in bytes.
+	Size int64
+	// Oldest and Newest are modification times of the oldest and the newest entries, they are zero if there are no entries.
+	Oldest time.Time
+	Newest time.Time
+}
+
+// Stats returns statistics of entries in the cache.
+func (c *Cache) Stats() (Stats, error) {
+	var stats Stats
+
+	err := filepath.WalkDir(filepath.Join(c.dir, modulesDir), func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(path, entryExt) {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		stats.Entries++
+		stats.Size += info.Size()
+		if stats.Oldest.IsZero() || info.ModTime().Before(stats.Oldest) {
+			stats.Oldest = info.ModTime()
+		}
+		if info.ModTime().After(stats.Newest) {
+			stats.Newest = info.ModTime()
+		}
+
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return stats, nil
+	}
+	if err != nil {
+		return stats, fmt.Errorf("cache %s: %w", c.dir, err)
+	}
+
+	return stats, nil
+}
+
+// Clean removes all entries from the cache.
+func (c *Cache) Clean() error {
+	return os.RemoveAll(filepath.Join(c.dir, modulesDir))
+}
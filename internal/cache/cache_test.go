@@ -0,0 +1,125 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/formatters"
+)
+
+func TestCache_PutGet(t *testing.T) {
+	c := New(t.TempDir())
+	key := NewKey().Sum()
+
+	_, ok := c.Get(key)
+	require.False(t, ok)
+
+	store := storage.NewUnstructuredObjectStore()
+	require.NoError(t, store.Put("test-module/templates/cm.yaml", map[string]any{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata":   map[string]any{"name": "cm", "namespace": "d8-test"},
+		"data":       map[string]any{"replicas": int64(3), "ratio": 0.5, "list": []any{int64(1), "a"}},
+	}, []byte("raw"), 0, 1, 4))
+	mdl := module.NewModuleFromStore("test-module", "d8-test", "/modules/test-module", store)
+
+	findings := errors.LintRuleErrorsList{}
+	findings.Add(errors.NewLintRuleError("ID", "cm", "test-module", map[string]string(nil), "nil map").WithRule("rule"))
+	findings.Add(errors.NewLintRuleError("ID", "cm", "test-module", struct{ A int }{A: 1}, "struct").
+		WithFilePath("templates/cm.yaml").WithPosition(1, 1))
+	findings.Add(errors.NewLintRuleError("ID", "cm", "test-module", nil, "no value").WithSeverity(errors.SeverityWarning))
+
+	require.NoError(t, c.Put(key, NewEntry(mdl, findings)))
+
+	entry, ok := c.Get(key)
+	require.True(t, ok)
+
+	restored := entry.Module("/modules/test-module")
+	require.Equal(t, "test-module", restored.GetName())
+	require.Equal(t, "d8-test", restored.GetNamespace())
+	require.Equal(t, mdl.GetStorage(), restored.GetStorage())
+
+	restoredFindings := entry.Errors()
+	errs := restoredFindings.GetErrors()
+	expected := findings.GetErrors()
+	require.Len(t, errs, len(expected))
+	for i, e := range errs {
+		require.Equal(t, expected[i].Text, e.Text)
+		require.Equal(t, expected[i].Rule, e.Rule)
+		require.Equal(t, expected[i].Severity, e.Severity)
+		require.Equal(t, expected[i].Location, e.Location)
+
+		// values are reported the same way as the original ones
+		require.Equal(t, expected[i].Value == nil, e.Value == nil)
+		require.Equal(t, fmt.Sprintf("%v", expected[i].Value), fmt.Sprintf("%v", e.Value))
+
+		expectedJSON, err := json.Marshal(formatters.JSONValue(expected[i].Value))
+		require.NoError(t, err)
+		actualJSON, err := json.Marshal(formatters.JSONValue(e.Value))
+		require.NoError(t, err)
+		require.JSONEq(t, string(expectedJSON), string(actualJSON))
+	}
+}
+
+func TestCache_StatsClean(t *testing.T) {
+	c := New(t.TempDir())
+
+	stats, err := c.Stats()
+	require.NoError(t, err)
+	require.Zero(t, stats.Entries)
+
+	for _, name := range []string{"a", "b"} {
+		key := NewKey()
+		key.Add("name", []byte(name))
+		require.NoError(t, c.Put(key.Sum(), &Entry{Name: name}))
+	}
+
+	stats, err = c.Stats()
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Entries)
+	require.Positive(t, stats.Size)
+	require.False(t, stats.Oldest.After(stats.Newest))
+
+	require.NoError(t, c.Clean())
+	stats, err = c.Stats()
+	require.NoError(t, err)
+	require.Zero(t, stats.Entries)
+}
+
+func TestKey(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "a.yaml"), []byte("a"), 0o600))
+
+	sum := func() string {
+		key := NewKey()
+		require.NoError(t, key.AddDir(dir))
+		require.NoError(t, key.AddFile(filepath.Join(dir, "missing.json")))
+
+		return key.Sum()
+	}
+
+	initial := sum()
+	require.Equal(t, initial, sum())
+
+	// renaming a file changes the key
+	require.NoError(t, os.Rename(filepath.Join(dir, "templates", "a.yaml"), filepath.Join(dir, "templates", "b.yaml")))
+	renamed := sum()
+	require.NotEqual(t, initial, renamed)
+
+	// changing a file changes the key
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "b.yaml"), []byte("b"), 0o600))
+	require.NotEqual(t, renamed, sum())
+
+	// the missing directory is a part of the key
+	key := NewKey()
+	require.NoError(t, key.AddDir(filepath.Join(dir, "missing")))
+}
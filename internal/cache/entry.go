@@ -0,0 +1,170 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/formatters"
+)
+
+// Entry is the cached result of linting a module: its rendered objects and findings of linters.
+type Entry struct {
+	Name      string    `json:"name"`
+	Namespace string    `json:"namespace"`
+	Objects   []Object  `json:"objects"`
+	Findings  []Finding `json:"findings"`
+}
+
+// Object is a rendered object of the module.
+type Object struct {
+	Path     string         `json:"path"`
+	Hash     string         `json:"hash"`
+	Document int            `json:"document"`
+	Line     int            `json:"line"`
+	EndLine  int            `json:"endLine"`
+	Object   map[string]any `json:"object"`
+}
+
+// Finding is an error found by a linter, before severity overrides, exclusions and directives are applied.
+type Finding struct {
+	Text     string `json:"text"`
+	ID       string `json:"id"`
+	ObjectID string `json:"objectId,omitempty"`
+	// Value is the JSON of the value, ValueText is its text, see value.
+	Value     any             `json:"value,omitempty"`
+	ValueText string          `json:"valueText,omitempty"`
+	HasValue  bool            `json:"hasValue,omitempty"`
+	Module    string          `json:"module"`
+	Linter    string          `json:"linter"`
+	Rule      string          `json:"rule,omitempty"`
+	Severity  errors.Severity `json:"severity,omitempty"`
+	Location  errors.Location `json:"location"`
+	Scenarios []string        `json:"scenarios,omitempty"`
+}
+
+// NewEntry returns the entry of the module with the findings.
+func NewEntry(mdl *module.Module, findings errors.LintRuleErrorsList) *Entry {
+	entry := &Entry{
+		Name:      mdl.GetName(),
+		Namespace: mdl.GetNamespace(),
+		Objects:   []Object{},
+		Findings:  []Finding{},
+	}
+
+	for _, object := range mdl.GetStorage() {
+		entry.Objects = append(entry.Objects, Object{
+			Path:     object.Path,
+			Hash:     object.Hash,
+			Document: object.Document,
+			Line:     object.Line,
+			EndLine:  object.EndLine,
+			Object:   object.Unstructured.Object,
+		})
+	}
+
+	for _, err := range findings.GetErrors() {
+		entry.Findings = append(entry.Findings, Finding{
+			Text:      err.Text,
+			ID:        err.ID,
+			ObjectID:  err.ObjectID,
+			Value:     formatters.JSONValue(err.Value),
+			ValueText: fmt.Sprintf("%v", err.Value),
+			HasValue:  err.Value != nil,
+			Module:    err.Module,
+			Linter:    err.Linter,
+			Rule:      err.Rule,
+			Severity:  err.Severity,
+			Location:  err.Location,
+			Scenarios: err.Scenarios,
+		})
+	}
+
+	return entry
+}
+
+// value is the restored value of a finding. Values of linters are not restored with their types,
+// so the value keeps the text and the JSON of the original one to be reported the same way.
+type value struct {
+	text string
+	json any
+}
+
+func (v value) Format(f fmt.State, _ rune) {
+	_, _ = io.WriteString(f, v.text)
+}
+
+func (v value) JSONValue() any {
+	return v.json
+}
+
+// Module returns the module with the rendered objects of the entry, the chart of the module is not loaded.
+func (e *Entry) Module(path string) *module.Module {
+	store := storage.NewUnstructuredObjectStore()
+	for _, object := range e.Objects {
+		storeObject := storage.StoreObject{
+			Path:         object.Path,
+			Hash:         object.Hash,
+			Document:     object.Document,
+			Line:         object.Line,
+			EndLine:      object.EndLine,
+			Unstructured: unstructured.Unstructured{Object: restoreNumbers(object.Object).(map[string]any)},
+		}
+		store.Storage[storage.GetResourceIndex(storeObject)] = storeObject
+	}
+
+	return module.NewModuleFromStore(e.Name, e.Namespace, path, store)
+}
+
+// restoreNumbers converts JSON numbers to int64 and float64 like they are in rendered objects.
+func restoreNumbers(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		for key, item := range v {
+			v[key] = restoreNumbers(item)
+		}
+	case []any:
+		for i, item := range v {
+			v[i] = restoreNumbers(item)
+		}
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return i
+		}
+		f, _ := v.Float64()
+		return f
+	}
+
+	return v
+}
+
+// Errors returns the findings of the entry.
+func (e *Entry) Errors() errors.LintRuleErrorsList {
+	result := errors.LintRuleErrorsList{}
+	for _, f := range e.Findings {
+		var v any
+		if f.HasValue {
+			v = value{text: f.ValueText, json: f.Value}
+		}
+
+		result.Add(&errors.LintRuleError{
+			Text:      f.Text,
+			ID:        f.ID,
+			ObjectID:  f.ObjectID,
+			Value:     v,
+			Module:    f.Module,
+			Linter:    f.Linter,
+			Rule:      f.Rule,
+			Severity:  f.Severity,
+			Location:  f.Location,
+			Scenarios: f.Scenarios,
+		})
+	}
+
+	return result
+}
@@ -0,0 +1,118 @@
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"strconv"
+	"sync"
+)
+
+// Key computes the key of an entry from named parts, the order of parts matters.
+type Key struct {
+	h hash.Hash
+}
+
+func NewKey() *Key {
+	return &Key{h: sha256.New()}
+}
+
+// Add adds the part with the data.
+func (k *Key) Add(name string, data []byte) {
+	// parts are prefixed with their lengths, so different parts never produce the same stream
+	_, _ = fmt.Fprintf(k.h, "%s:%d:", name, len(data))
+	_, _ = k.h.Write(data)
+}
+
+// AddFile adds the content of the file, a missing file is a part without data.
+func (k *Key) AddFile(path string) error {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		k.Add("missing "+path, nil)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	k.Add("file "+path, content)
+
+	return nil
+}
+
+// AddDir adds paths relative to the directory and contents of all files in it, a missing directory is a part without data.
+func (k *Key) AddDir(dir string) error {
+	return filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if errors.Is(err, fs.ErrNotExist) && path == dir {
+			k.Add("missing "+dir, nil)
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		h := sha256.New()
+		if _, err = io.Copy(h, f); err != nil {
+			return err
+		}
+		k.Add("file "+filepath.ToSlash(rel), h.Sum(nil))
+
+		return nil
+	})
+}
+
+// Sum returns the key.
+func (k *Key) Sum() string {
+	return hex.EncodeToString(k.h.Sum(nil))
+}
+
+// Version identifies the build of dmt: the version and the VCS revision of the main module.
+// If the revision is unknown or the tree was modified, the size and the modification time of the executable are added,
+// so entries of development builds are not reused by other builds.
+var Version = sync.OnceValue(func() string {
+	version := "unknown"
+	revision, modified := "", false
+	if info, ok := debug.ReadBuildInfo(); ok {
+		version = info.Main.Version
+		for _, s := range info.Settings {
+			switch s.Key {
+			case "vcs.revision":
+				revision = s.Value
+			case "vcs.modified":
+				modified = s.Value == "true"
+			}
+		}
+	}
+
+	version += " " + revision
+	if revision == "" || modified {
+		if exe, err := os.Executable(); err == nil {
+			if info, err := os.Stat(exe); err == nil {
+				version += " " + strconv.FormatInt(info.Size(), 10) + " " + info.ModTime().UTC().String()
+			}
+		}
+	}
+
+	return version
+})
@@ -0,0 +1,207 @@
+// Package changes finds files and lines changed in git repositories since a revision,
+// they are used to lint only modules affected by a branch.
+package changes
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// lineRange is a range of lines in the new version of a file, both ends are included.
+type lineRange struct {
+	from, to int
+}
+
+// Changes contains files changed since the revision by their absolute paths.
+type Changes struct {
+	// files contains ranges of added and modified lines of changed files, files with only deleted lines have no ranges.
+	files map[string][]lineRange
+	// whole contains files which are changed entirely: untracked, deleted and binary files.
+	whole map[string]bool
+}
+
+// Load finds files changed in git repositories of the directories since the merge base of the revision and HEAD.
+// Uncommitted changes and untracked files are included.
+func Load(ctx context.Context, dirs []string, rev string) (*Changes, error) {
+	c := &Changes{files: make(map[string][]lineRange), whole: make(map[string]bool)}
+
+	var roots []string
+	for _, dir := range dirs {
+		root, err := git(ctx, dir, "rev-parse", "--show-toplevel")
+		if err != nil {
+			return nil, err
+		}
+		root = strings.TrimSpace(root)
+		if slices.Contains(roots, root) {
+			continue
+		}
+		roots = append(roots, root)
+
+		base, err := git(ctx, root, "merge-base", rev, "HEAD")
+		if err != nil {
+			return nil, err
+		}
+
+		diff, err := git(ctx, root, "diff", "--unified=0", "--no-color", "--no-ext-diff", "--no-renames", strings.TrimSpace(base))
+		if err != nil {
+			return nil, err
+		}
+		if err = c.parseDiff(root, diff); err != nil {
+			return nil, err
+		}
+
+		untracked, err := git(ctx, root, "ls-files", "--others", "--exclude-standard")
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range strings.Split(untracked, "\n") {
+			if file != "" {
+				c.whole[filepath.Join(root, filepath.FromSlash(file))] = true
+			}
+		}
+	}
+
+	return c, nil
+}
+
+// git runs the git command in the directory and returns its output.
+func git(ctx context.Context, dir string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-c", "core.quotePath=off", "-C", dir}, args...)...)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git %s: %w: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+
+	return string(out), nil
+}
+
+var hunkRe = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// parseDiff records changed files and lines from the diff with zero context lines of the repository in the root.
+func (c *Changes) parseDiff(root, diff string) error {
+	var oldFile, file string
+
+	scanner := bufio.NewScanner(strings.NewReader(diff))
+	scanner.Buffer(nil, 1<<20)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			oldFile, file = "", ""
+			// binary files have no ---/+++ lines, so the path is taken from this line
+			rest := strings.TrimPrefix(line, "diff --git a/")
+			if i := strings.LastIndex(rest, " b/"); i >= 0 {
+				oldFile = filepath.Join(root, filepath.FromSlash(rest[:i]))
+			}
+		case strings.HasPrefix(line, "--- "):
+			oldFile = diffPath(root, strings.TrimPrefix(line, "--- "), "a/")
+		case strings.HasPrefix(line, "+++ "):
+			file = diffPath(root, strings.TrimPrefix(line, "+++ "), "b/")
+			if file == "" {
+				// the file is deleted, it is changed but has no lines
+				c.whole[oldFile] = true
+				continue
+			}
+			if _, ok := c.files[file]; !ok {
+				c.files[file] = nil
+			}
+		case strings.HasPrefix(line, "Binary files ") && oldFile != "":
+			c.whole[oldFile] = true
+		case strings.HasPrefix(line, "@@ ") && file != "":
+			m := hunkRe.FindStringSubmatch(line)
+			if m == nil {
+				return fmt.Errorf("unexpected hunk header %q of %s", line, file)
+			}
+
+			from, _ := strconv.Atoi(m[1])
+			count := 1
+			if m[2] != "" {
+				count, _ = strconv.Atoi(m[2])
+			}
+			if count > 0 {
+				c.files[file] = append(c.files[file], lineRange{from: from, to: from + count - 1})
+			}
+		}
+	}
+
+	return scanner.Err()
+}
+
+// diffPath returns the absolute path of the file in the "---" or "+++" line, it is empty for /dev/null.
+func diffPath(root, path, prefix string) string {
+	path, _, _ = strings.Cut(path, "\t")
+	if path == "/dev/null" {
+		return ""
+	}
+
+	return filepath.Join(root, filepath.FromSlash(strings.TrimPrefix(path, prefix)))
+}
+
+// Files returns absolute paths of all changed files, including deleted ones.
+func (c *Changes) Files() []string {
+	files := make([]string, 0, len(c.files)+len(c.whole))
+	for file := range c.files {
+		files = append(files, file)
+	}
+	for file := range c.whole {
+		if _, ok := c.files[file]; !ok {
+			files = append(files, file)
+		}
+	}
+	slices.Sort(files)
+
+	return files
+}
+
+// Contains returns true if any line from line to endLine of the file is changed, endLine 0 means the single line.
+// The line 0 means any line of the file.
+func (c *Changes) Contains(file string, line, endLine int) bool {
+	if c.whole[file] {
+		return true
+	}
+
+	ranges, ok := c.files[file]
+	if !ok {
+		return false
+	}
+	if line == 0 {
+		return true
+	}
+
+	endLine = max(endLine, line)
+
+	return slices.ContainsFunc(ranges, func(r lineRange) bool {
+		return line <= r.to && endLine >= r.from
+	})
+}
+
+// Filter returns findings in changed lines of changed files, paths of findings are relative to their modules.
+// Findings of rendered objects are kept if any line of the object document is changed.
+// Findings without a file are kept, they can't be attributed to a change.
+func (c *Changes) Filter(list *errors.LintRuleErrorsList, modulePaths map[string]string) *errors.LintRuleErrorsList {
+	result := &errors.LintRuleErrorsList{}
+	for _, e := range list.GetErrors() {
+		path, ok := modulePaths[e.Module]
+		if resolved, err := filepath.EvalSymlinks(path); err == nil {
+			path = resolved
+		}
+		if e.Location.File == "" || !ok || c.Contains(filepath.Join(path, e.Location.File), e.Location.Line, e.Location.EndLine) {
+			result.Add(e)
+		}
+	}
+
+	return result
+}
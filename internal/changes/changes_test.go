@@ -0,0 +1,169 @@
+package changes
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	out, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(out))
+}
+
+func TestLoad(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+
+	runGit(t, dir, "init", "-q", "-b", "main")
+	writeFile(t, filepath.Join(dir, "modules", "a", "values.yaml"), "one\ntwo\nthree\nfour\n")
+	writeFile(t, filepath.Join(dir, "modules", "b", "values.yaml"), "one\n")
+	writeFile(t, filepath.Join(dir, "modules", "c", "values.yaml"), "one\n")
+	writeFile(t, filepath.Join(dir, "modules", "d", "values.yaml"), "one\ntwo\n")
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+
+	runGit(t, dir, "checkout", "-q", "-b", "feature")
+	writeFile(t, filepath.Join(dir, "modules", "a", "values.yaml"), "one\n2\nthree\nfour\nfive\n")
+	require.NoError(t, os.Remove(filepath.Join(dir, "modules", "b", "values.yaml")))
+	runGit(t, dir, "commit", "-q", "-am", "change")
+
+	// uncommitted and untracked files are changes as well
+	writeFile(t, filepath.Join(dir, "modules", "d", "values.yaml"), "one\n")
+	writeFile(t, filepath.Join(dir, "modules", "e", "values.yaml"), "one\n")
+
+	c, err := Load(context.Background(), []string{filepath.Join(dir, "modules")}, "main")
+	require.NoError(t, err)
+
+	require.Equal(t, []string{
+		filepath.Join(dir, "modules", "a", "values.yaml"),
+		filepath.Join(dir, "modules", "b", "values.yaml"),
+		filepath.Join(dir, "modules", "d", "values.yaml"),
+		filepath.Join(dir, "modules", "e", "values.yaml"),
+	}, c.Files())
+
+	a := filepath.Join(dir, "modules", "a", "values.yaml")
+	require.True(t, c.Contains(a, 0, 0))
+	require.False(t, c.Contains(a, 1, 0))
+	require.True(t, c.Contains(a, 2, 0))
+	require.False(t, c.Contains(a, 4, 0))
+	require.True(t, c.Contains(a, 5, 0))
+	// ranges are changed if any of their lines is changed
+	require.True(t, c.Contains(a, 1, 3))
+	require.False(t, c.Contains(a, 3, 4))
+
+	// only a line is deleted
+	d := filepath.Join(dir, "modules", "d", "values.yaml")
+	require.True(t, c.Contains(d, 0, 0))
+	require.False(t, c.Contains(d, 1, 0))
+
+	require.True(t, c.Contains(filepath.Join(dir, "modules", "e", "values.yaml"), 1, 0))
+	require.False(t, c.Contains(filepath.Join(dir, "modules", "c", "values.yaml"), 0, 0))
+
+	list := &errors.LintRuleErrorsList{}
+	list.Add(errors.NewLintRuleError("id", "", "a", nil, "changed line").WithFilePath("values.yaml").WithPosition(2, 1))
+	list.Add(errors.NewLintRuleError("id", "", "a", nil, "unchanged line").WithFilePath("values.yaml").WithPosition(3, 1))
+	list.Add(errors.NewLintRuleError("id", "", "a", nil, "no file"))
+	list.Add(errors.NewLintRuleError("id", "", "c", nil, "unchanged file").WithFilePath("values.yaml"))
+
+	filtered := c.Filter(list, map[string]string{
+		"a": filepath.Join(dir, "modules", "a"),
+		"c": filepath.Join(dir, "modules", "c"),
+	})
+
+	var texts []string
+	for _, e := range filtered.GetErrors() {
+		texts = append(texts, e.Text)
+	}
+	require.ElementsMatch(t, []string{"changed line", "no file"}, texts)
+}
+
+func TestFilter_renderedObject(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	modulePath := filepath.Join(dir, "test-module")
+
+	runGit(t, dir, "init", "-q", "-b", "main")
+	writeFile(t, filepath.Join(modulePath, "Chart.yaml"), "name: test-module\nversion: 0.1.0\n")
+	writeFile(t, filepath.Join(modulePath, "openapi", "values.yaml"), "type: object\n")
+	template := `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: first
+data:
+  key: %s
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: second
+`
+	writeFile(t, filepath.Join(modulePath, "templates", "cm.yaml"), fmt.Sprintf(template, "a"))
+	runGit(t, dir, "add", "-A")
+	runGit(t, dir, "commit", "-q", "-m", "initial")
+
+	// the line in the middle of the first document is changed
+	writeFile(t, filepath.Join(modulePath, "templates", "cm.yaml"), fmt.Sprintf(template, "b"))
+
+	c, err := Load(context.Background(), []string{dir}, "main")
+	require.NoError(t, err)
+
+	m, err := module.NewModule(modulePath)
+	require.NoError(t, err)
+
+	// findings of rendered objects point at the start of their documents
+	list := &errors.LintRuleErrorsList{}
+	for _, object := range m.GetStorage() {
+		list.Add(errors.NewLintRuleError("id", object.Identity(), "test-module", nil, "%s", object.Unstructured.GetName()).
+			WithLocation(object.Location()))
+	}
+
+	var texts []string
+	for _, e := range c.Filter(list, map[string]string{"test-module": modulePath}).GetErrors() {
+		texts = append(texts, e.Text)
+	}
+	require.Equal(t, []string{"first"}, texts)
+}
+
+func TestLoad_UnknownRevision(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	dir := t.TempDir()
+	runGit(t, dir, "init", "-q")
+
+	_, err := Load(context.Background(), []string{dir}, "unknown")
+	require.Error(t, err)
+}
@@ -3,6 +3,7 @@ package flags
 import (
 	"fmt"
 	"os"
+	"time"
 
 	"github.com/deckhouse/dmt/internal/logger"
 
@@ -11,11 +12,45 @@ import (
 
 const (
 	numThreads = 10
+	// expiringWithin is the default period of the summary of expiring exclusions.
+	expiringWithin = 30 * 24 * time.Hour
+	// linterTimeout is the default limit of the run of a linter on a module.
+	linterTimeout = 5 * time.Minute
 )
 
 var (
 	LintersLimit int
 	LogLevel     string
+	Format       string
+	GenOutput    string
+	ConfigPath   string
+
+	AllowRulePacks bool
+
+	Baseline      string
+	WriteBaseline string
+
+	Enable  []string
+	Disable []string
+
+	FailOn string
+
+	ReportUnusedExclusions bool
+	RemoveUnusedExclusions bool
+	ExpiringWithin         time.Duration
+
+	LinterTimeout time.Duration
+
+	NoCache  bool
+	CacheDir string
+
+	ChangedSince string
+	ChangedLines bool
+
+	Watch bool
+
+	ValuesFiles  []string
+	RenderOutput string
 )
 
 var (
@@ -30,7 +65,7 @@ func InitDefaultFlagSet() *pflag.FlagSet {
 	defaults.BoolVarP(&PrintVersion, "version", "v", false, "version message")
 
 	defaults.Usage = func() {
-		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt [gen|lint] [OPTIONS]")
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt [gen|lint|render|config|cache] [OPTIONS]")
 		defaults.PrintDefaults()
 	}
 
@@ -40,8 +75,31 @@ func InitDefaultFlagSet() *pflag.FlagSet {
 func InitLintFlagSet() *pflag.FlagSet {
 	lint := pflag.NewFlagSet("lint", pflag.ContinueOnError)
 
-	lint.IntVarP(&LintersLimit, "parallel", "p", numThreads, "number of threads for parallel processing")
+	lint.IntVarP(&LintersLimit, "parallel", "p", numThreads, "number of modules loading and linters running at the same time")
+	lint.DurationVar(&LinterTimeout, "timeout", linterTimeout, "limit of the run of a linter on a module, 0 disables the limit")
 	lint.StringVarP(&LogLevel, "log-level", "l", "INFO", "log-level [DEBUG | INFO | WARN | ERROR]")
+	lint.StringVarP(&Format, "format", "f", "text", "output format [text | json | sarif | junit]")
+	lint.StringVar(&Baseline, "baseline", "", "baseline file, findings recorded in it are not reported")
+	lint.StringVar(&WriteBaseline, "write-baseline", "", "write all findings to the baseline file")
+	lint.StringSliceVar(&Enable, "enable", nil, "run only these linters or rules (<linter> or <linter>/<rule>)")
+	lint.StringSliceVar(&Disable, "disable", nil, "do not run these linters or rules (<linter> or <linter>/<rule>)")
+	lint.StringVar(&FailOn, "fail-on", "error", "exit with code 1 if there are findings of this or higher severity [info | warning | error]")
+
+	lint.BoolVar(&ReportUnusedExclusions, "report-unused-exclusions", false, "report exclusions in config files which did not match anything")
+	lint.BoolVar(&RemoveUnusedExclusions, "remove-unused-exclusions", false, "remove exclusions which did not match anything from config files")
+	lint.DurationVar(&ExpiringWithin, "expiring-within", expiringWithin, "list exclusions which expire within this period, 0 disables the list")
+	lint.StringVarP(&ConfigPath, "config", "c", "", "config file, by default .dmtlint files are searched in the linted directory and its parents")
+	lint.BoolVar(&AllowRulePacks, "allow-rule-packs", false, "run rule packs of all config files, not only of the --config file and the home directory config")
+
+	lint.StringVar(&ChangedSince, "changed-since", "", "lint only modules changed since the merge base of this git revision and HEAD")
+	lint.BoolVar(&ChangedLines, "changed-lines", false, "report only findings in changed lines of changed files (requires --changed-since)")
+
+	lint.StringArrayVar(&ValuesFiles, "values", nil, "values file to lint modules with in addition to generated values, every file is a separate scenario, can be repeated")
+
+	lint.BoolVar(&Watch, "watch", false, "lint modules again when their files change and print new and fixed findings")
+
+	lint.BoolVar(&NoCache, "no-cache", false, "lint all modules instead of restoring findings of unchanged modules from the cache")
+	lint.StringVar(&CacheDir, "cache-dir", "", "cache directory, $XDG_CACHE_HOME/dmt by default")
 
 	lint.Usage = func() {
 		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt lint [OPTIONS] [dirs...]")
@@ -54,14 +112,58 @@ func InitLintFlagSet() *pflag.FlagSet {
 func InitGenFlagSet() *pflag.FlagSet {
 	gen := pflag.NewFlagSet("gen", pflag.ContinueOnError)
 
+	gen.StringVarP(&GenOutput, "output", "o", ".", "directory to create the module in (gen module)")
+	gen.BoolVar(&AllowRulePacks, "allow-rule-packs", false, "run rule packs of all config files, not only of the home directory config (gen vpa|pdb)")
+
 	gen.Usage = func() {
-		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt gen [OPTIONS]")
-		pflag.PrintDefaults()
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt gen module [OPTIONS] <name> | dmt gen vpa|pdb [dirs...]")
+		gen.PrintDefaults()
 	}
 
 	return gen
 }
 
+func InitConfigFlagSet() *pflag.FlagSet {
+	cfg := pflag.NewFlagSet("config", pflag.ContinueOnError)
+
+	cfg.StringVarP(&ConfigPath, "config", "c", "", "config file, by default .dmtlint files are searched in the linted directory and its parents")
+	cfg.BoolVar(&AllowRulePacks, "allow-rule-packs", false, "run rule packs of all config files, not only of the --config file and the home directory config")
+
+	cfg.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt config show [OPTIONS] [dirs...] | dmt config schema")
+		cfg.PrintDefaults()
+	}
+
+	return cfg
+}
+
+func InitRenderFlagSet() *pflag.FlagSet {
+	render := pflag.NewFlagSet("render", pflag.ContinueOnError)
+
+	render.StringArrayVar(&ValuesFiles, "values", nil, "values file merged into values generated from OpenAPI schemas, can be repeated")
+	render.StringVarP(&RenderOutput, "output", "o", "", "directory to write values and one file per rendered object to")
+
+	render.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt render [OPTIONS] <module dir>")
+		render.PrintDefaults()
+	}
+
+	return render
+}
+
+func InitCacheFlagSet() *pflag.FlagSet {
+	cache := pflag.NewFlagSet("cache", pflag.ContinueOnError)
+
+	cache.StringVar(&CacheDir, "cache-dir", "", "cache directory, $XDG_CACHE_HOME/dmt by default")
+
+	cache.Usage = func() {
+		_, _ = fmt.Fprintln(os.Stderr, "Usage: dmt cache clean|stats [OPTIONS]")
+		cache.PrintDefaults()
+	}
+
+	return cache
+}
+
 func GeneralParse(flagSet *pflag.FlagSet) {
 	if err := flagSet.Parse(os.Args[1:]); err != nil {
 		flagSet.Usage()
@@ -0,0 +1,106 @@
+package generators
+
+import (
+	"bytes"
+	"cmp"
+	"embed"
+	"fmt"
+	"path"
+	"slices"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/linters/k8s-resources/pdb"
+	"github.com/deckhouse/dmt/pkg/linters/k8s-resources/vpa"
+)
+
+const snippetsDir = "snippets"
+
+//go:embed snippets
+var snippets embed.FS
+
+type vpaData struct {
+	Kind                   string
+	Name                   string
+	Namespace              string
+	WorkloadResourcePolicy string
+	Containers             []string
+}
+
+type pdbData struct {
+	Name      string
+	Namespace string
+	Selector  map[string]string
+}
+
+// VPA returns helm templates of VerticalPodAutoscalers for pod controllers of the module which are not covered by VPA,
+// every VPA has resource policies for all containers of the controller.
+func VPA(m *module.Module) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	for _, object := range sortObjects(vpa.ControllersWithoutVPA(m)) {
+		containers, err := object.GetContainers()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", object.Identity(), err)
+		}
+
+		policy, err := vpa.WorkloadResourcePolicy(object)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", object.Identity(), err)
+		}
+
+		data := vpaData{
+			Kind:                   object.Unstructured.GetKind(),
+			Name:                   object.Unstructured.GetName(),
+			Namespace:              object.Unstructured.GetNamespace(),
+			WorkloadResourcePolicy: policy,
+		}
+		for i := range containers {
+			data.Containers = append(data.Containers, containers[i].Name)
+		}
+
+		content, err := renderTemplate(snippets, path.Join(snippetsDir, "vpa.yaml.tmpl"), data)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(content)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// PDB returns helm templates of PodDisruptionBudgets for pod controllers of the module which pods are not covered
+// by PDB, selectors of PDBs match pod labels of the controllers.
+func PDB(m *module.Module) ([]byte, error) {
+	buf := &bytes.Buffer{}
+	for _, object := range sortObjects(pdb.ControllersWithoutPDB(m)) {
+		selector, err := pdb.PodSelector(object)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", object.Identity(), err)
+		}
+		if len(selector) == 0 {
+			return nil, fmt.Errorf("%s: pods of the controller have no labels", object.Identity())
+		}
+
+		data := pdbData{
+			Name:      object.Unstructured.GetName(),
+			Namespace: object.Unstructured.GetNamespace(),
+			Selector:  selector,
+		}
+
+		content, err := renderTemplate(snippets, path.Join(snippetsDir, "pdb.yaml.tmpl"), data)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(content)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func sortObjects(objects []storage.StoreObject) []storage.StoreObject {
+	slices.SortFunc(objects, func(a, b storage.StoreObject) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Identity(), b.Identity()))
+	})
+
+	return objects
+}
@@ -0,0 +1,71 @@
+package generators
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/moduletest"
+)
+
+const testControllers = `---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: d8-test
+spec:
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+        tier: frontend
+    spec:
+      containers:
+      - name: web
+        image: web
+      - name: proxy
+        image: proxy
+---
+apiVersion: apps/v1
+kind: DaemonSet
+metadata:
+  name: agent
+  namespace: d8-test
+spec:
+  template:
+    metadata:
+      labels:
+        app: agent
+    spec:
+      tolerations:
+      - operator: Exists
+      containers:
+      - name: agent
+        image: agent
+`
+
+func TestVPAAndPDB(t *testing.T) {
+	m := moduletest.New(t, map[string]string{
+		".namespace":                 "d8-test\n",
+		"templates/controllers.yaml": testControllers,
+	})
+
+	content, err := VPA(m)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "kind: Deployment\n    name: web\n")
+	require.Contains(t, string(content), "- containerName: web\n")
+	require.Contains(t, string(content), "- containerName: proxy\n")
+	require.Contains(t, string(content), "kind: DaemonSet\n    name: agent\n")
+	require.Contains(t, string(content), `"workload-resource-policy.deckhouse.io" "every-node"`)
+
+	content, err = PDB(m)
+	require.NoError(t, err)
+	require.Contains(t, string(content), "name: web\n")
+	require.Contains(t, string(content), "matchLabels:\n      app: \"web\"\n")
+	require.NotContains(t, string(content), "agent")
+	require.NotContains(t, string(content), "tier")
+}
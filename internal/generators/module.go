@@ -0,0 +1,123 @@
+package generators
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/deckhouse/dmt/internal/logger"
+)
+
+const (
+	moduleTemplateDir = "module-template"
+	templateSuffix    = ".tmpl"
+
+	dirPermissions  = 0o755
+	filePermissions = 0o644
+)
+
+// moduleTemplate contains the skeleton of a module, every file is a text/template with [[ ]] delimiters,
+// so helm templates inside it are kept as is.
+//
+//go:embed all:module-template
+var moduleTemplate embed.FS
+
+var moduleNameRe = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+
+type moduleData struct {
+	Name      string
+	Namespace string
+}
+
+// Module creates a skeleton of a new module with the given name in the dir directory
+// and returns the path of the created module.
+func Module(name, dir string) (string, error) {
+	if !moduleNameRe.MatchString(name) {
+		return "", fmt.Errorf("invalid module name %q: it must consist of lower case alphanumeric characters or '-'", name)
+	}
+
+	modulePath := filepath.Join(dir, name)
+	if _, err := os.Stat(modulePath); err == nil {
+		return "", fmt.Errorf("%s already exists", modulePath)
+	}
+
+	data := moduleData{
+		Name:      name,
+		Namespace: "d8-" + name,
+	}
+
+	err := fs.WalkDir(moduleTemplate, moduleTemplateDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel := strings.TrimPrefix(strings.TrimPrefix(p, moduleTemplateDir), "/")
+		target := filepath.Join(modulePath, filepath.FromSlash(strings.TrimSuffix(rel, templateSuffix)))
+
+		if d.IsDir() {
+			//nolint:gosec // module sources are not secret
+			return os.MkdirAll(target, dirPermissions)
+		}
+
+		content, err := renderTemplate(moduleTemplate, p, data)
+		if err != nil {
+			return err
+		}
+
+		//nolint:gosec // module sources are not secret
+		return os.WriteFile(target, content, filePermissions)
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate module: %w", err)
+	}
+
+	return modulePath, nil
+}
+
+// renderTemplate executes the text/template file from fsys, templates use [[ ]] delimiters.
+func renderTemplate(fsys embed.FS, p string, data any) ([]byte, error) {
+	raw, err := fsys.ReadFile(p)
+	if err != nil {
+		return nil, err
+	}
+
+	tpl, err := template.New(path.Base(p)).Delims("[[", "]]").Parse(string(raw))
+	if err != nil {
+		return nil, fmt.Errorf("parse %s: %w", p, err)
+	}
+
+	buf := &bytes.Buffer{}
+	if err = tpl.Execute(buf, data); err != nil {
+		return nil, fmt.Errorf("execute %s: %w", p, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// UpdateModuleDependencies downloads chart dependencies of the module (deckhouse_lib_helm) with helm,
+// templates of the module can't be rendered without them.
+func UpdateModuleDependencies(modulePath string) {
+	helmPath, err := exec.LookPath("helm")
+	if err != nil {
+		logger.WarnF("helm is not found, run `helm dependency update %s` to download module dependencies, "+
+			"dmt lint skips the module until they are downloaded", modulePath)
+		return
+	}
+
+	out, err := exec.Command(helmPath, "dependency", "update", modulePath).CombinedOutput()
+	if err != nil {
+		logger.WarnF("Cannot download module dependencies, run `helm dependency update %s` manually, "+
+			"dmt lint skips the module until they are downloaded:\n%s", modulePath, out)
+		return
+	}
+
+	logger.InfoF("Module dependencies are downloaded")
+}
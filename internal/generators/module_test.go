@@ -0,0 +1,60 @@
+package generators
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/dmt"
+	"github.com/deckhouse/dmt/pkg/linters/monitoring"
+)
+
+func TestModule(t *testing.T) {
+	dir := t.TempDir()
+
+	modulePath, err := Module("test-module", dir)
+	require.NoError(t, err)
+	require.Equal(t, filepath.Join(dir, "test-module"), modulePath)
+
+	for _, file := range []string{
+		".helmignore", ".namespace", "Chart.yaml", "module.yaml", "oss.yaml",
+		"openapi/config-values.yaml", "openapi/values.yaml",
+		"templates/monitoring.yaml", "templates/rbac-for-us.yaml",
+	} {
+		require.FileExists(t, filepath.Join(modulePath, file))
+	}
+
+	namespace, err := os.ReadFile(filepath.Join(modulePath, ".namespace"))
+	require.NoError(t, err)
+	require.Equal(t, "d8-test-module\n", string(namespace))
+
+	require.Nil(t, monitoring.MonitoringModuleRule(&config.MonitoringSettings{}, "test-module", modulePath, "d8-test-module"))
+
+	_, err = Module("test-module", dir)
+	require.Error(t, err)
+
+	_, err = Module("Test_Module", dir)
+	require.Error(t, err)
+}
+
+// TestModule_lint checks that the generated module is rendered and passes all linters.
+// Templates include helpers of deckhouse_lib_helm, a stub of them from testdata replaces the downloaded chart.
+func TestModule_lint(t *testing.T) {
+	modulePath, err := Module("test-module", t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.CopyFS(filepath.Join(modulePath, "charts", "deckhouse_lib_helm"), os.DirFS("testdata/deckhouse_lib_helm")))
+
+	cfg, err := config.NewDefault([]string{modulePath}, config.LoaderOptions{})
+	require.NoError(t, err)
+
+	result, err := dmt.Lint(context.Background(), cfg, []string{modulePath}, dmt.Options{})
+	require.NoError(t, err)
+	require.Len(t, result.Modules, 1)
+	for _, e := range result.Findings.GetErrors() {
+		t.Errorf("%s/%s: %s (%s)", e.Linter, e.Rule, e.Text, e.ObjectID)
+	}
+}
@@ -0,0 +1,25 @@
+{{- define "helm_lib_module_labels" }}
+{{- $context := index . 0 }}
+labels:
+  heritage: deckhouse
+  module: {{ $context.Chart.Name }}
+{{- if eq (len .) 2 }}
+{{- range $k, $v := index . 1 }}
+  {{ $k }}: {{ $v | quote }}
+{{- end }}
+{{- end }}
+{{- end }}
+{{- define "helm_lib_kube_rbac_proxy_ca_certificate" }}
+{{- $context := index . 0 }}
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: kube-rbac-proxy-ca.crt
+  namespace: {{ index . 1 }}
+  {{- include "helm_lib_module_labels" (list $context) | nindent 2 }}
+data:
+  ca.crt: "x"
+{{- end }}
+{{- define "helm_lib_prometheus_rules" }}
+{{- end }}
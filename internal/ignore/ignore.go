@@ -0,0 +1,354 @@
+// Package ignore implements inline suppression of findings with "dmt:ignore" directives.
+//
+// A directive is a YAML comment or a helm template comment:
+//
+//	# dmt:ignore <linter> [rule] -- <reason>
+//	{{/* dmt:ignore <linter> [rule] -- <reason> */}}
+//
+// A directive on its own line applies to the next object (if it precedes the first key of a document),
+// otherwise to the next key with its nested block. A directive at the end of a line applies to this line.
+//
+// Findings of rendered objects are located by the whole document of the object in the template, positions of keys
+// are not known. So directives in templates must precede objects, key and line directives are allowed only in other
+// files (e.g. OpenAPI files), they are reported as misplaced in templates.
+package ignore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/deckhouse/dmt/internal/fsutils"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	// Linter is the name reported for findings about directives.
+	Linter = config.ToolLinter
+	// ID is the ID of findings about directives.
+	ID = "ignore"
+
+	marker            = "dmt:ignore"
+	reasonSeparator   = "--"
+	documentSeparator = "---"
+	templatesDir      = "templates/"
+)
+
+var (
+	fileExtensions = []string{".yaml", ".yml", ".tpl"}
+
+	ownLineRe   = regexp.MustCompile(`^\s*(?:#|\{\{-?\s*/\*)\s*dmt:ignore\b(.*)$`)
+	trailingRe  = regexp.MustCompile(`\s(?:#|\{\{-?\s*/\*)\s*dmt:ignore\b(.*)$`)
+	commentEnd  = regexp.MustCompile(`\*/\s*-?\}\}\s*$`)
+	templateRe  = regexp.MustCompile(`^\s*\{\{.*\}\}\s*$`)
+	listItemRe  = regexp.MustCompile(`^\s*- `)
+	separatorRe = regexp.MustCompile(`^---\s*$`)
+)
+
+// Directive is a parsed "dmt:ignore" comment.
+type Directive struct {
+	// File is the path of the file with the directive, relative to the module root.
+	File string
+	// Line is the line of the directive in the file.
+	Line int
+	// Text is the directive as it is written in the file.
+	Text string
+
+	Linter string
+	Rule   string
+	Reason string
+
+	// start and end are the lines the directive applies to
+	start, end int
+	// document is the first line of the document containing the directive
+	document int
+	// object reports whether the directive precedes an object and applies to the whole object
+	object bool
+	// misplaced reports whether the directive in a template does not precede an object, it suppresses nothing
+	misplaced bool
+	used      bool
+}
+
+// Load parses directives from YAML files and helm templates of the module, subcharts are skipped.
+func Load(modulePath string) ([]*Directive, error) {
+	files, err := fsutils.GetFiles(modulePath, false)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []*Directive
+	for _, file := range files {
+		if !slices.Contains(fileExtensions, filepath.Ext(file)) {
+			continue
+		}
+
+		rel, err := filepath.Rel(modulePath, file)
+		if err != nil {
+			return nil, err
+		}
+
+		if strings.HasPrefix(rel, "charts"+string(os.PathSeparator)) {
+			continue
+		}
+
+		content, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, Parse(rel, string(content))...)
+	}
+
+	return result, nil
+}
+
+// Parse returns directives from the file content.
+func Parse(file, content string) []*Directive {
+	if !strings.Contains(content, marker) {
+		return nil
+	}
+
+	lines := strings.Split(content, "\n")
+
+	var result []*Directive
+	for i, line := range lines {
+		if !strings.Contains(line, marker) {
+			continue
+		}
+
+		lineNumber := i + 1
+
+		if match := ownLineRe.FindStringSubmatch(line); match != nil {
+			d := newDirective(file, lineNumber, line, match[1])
+			d.start, d.end, d.document, d.object = ownLineScope(lines, i)
+			result = append(result, d)
+
+			continue
+		}
+
+		if match := trailingRe.FindStringSubmatch(line); match != nil {
+			d := newDirective(file, lineNumber, line, match[1])
+			d.start, d.end, d.document = lineNumber, lineNumber, documentStart(lines, i)
+			result = append(result, d)
+		}
+	}
+
+	for _, d := range result {
+		d.misplaced = strings.HasPrefix(file, templatesDir) && !d.object
+	}
+
+	return result
+}
+
+func newDirective(file string, line int, text, args string) *Directive {
+	args = commentEnd.ReplaceAllString(args, "")
+
+	var reason string
+	if idx := strings.Index(args, reasonSeparator); idx >= 0 {
+		reason = strings.TrimSpace(args[idx+len(reasonSeparator):])
+		args = args[:idx]
+	}
+
+	d := &Directive{
+		File:   file,
+		Line:   line,
+		Text:   strings.TrimSpace(text),
+		Reason: reason,
+	}
+
+	fields := strings.Fields(args)
+	if len(fields) > 0 {
+		d.Linter = fields[0]
+	}
+	if len(fields) > 1 {
+		d.Rule = fields[1]
+	}
+
+	return d
+}
+
+// ownLineScope returns the lines (starting from 1) covered by the directive on the line with index i,
+// the first line of the document the directive belongs to and whether the directive precedes an object.
+func ownLineScope(lines []string, i int) (start, end, document int, object bool) {
+	target := -1
+	for j := i + 1; j < len(lines); j++ {
+		line := lines[j]
+		if strings.TrimSpace(line) == "" || isComment(line) || templateRe.MatchString(line) || separatorRe.MatchString(line) {
+			continue
+		}
+
+		target = j
+		break
+	}
+
+	if target < 0 {
+		return i + 1, i + 1, documentStart(lines, i), false
+	}
+
+	if indent(lines[target]) == 0 && isFirstContentLine(lines, target) {
+		// the directive precedes the object, it covers the whole document
+		document = documentStart(lines, target)
+		end = len(lines)
+		for j := target + 1; j < len(lines); j++ {
+			if separatorRe.MatchString(lines[j]) {
+				end = j
+				break
+			}
+		}
+
+		return min(i+1, document), end, document, true
+	}
+
+	// the directive precedes a key, it covers the key with its nested block
+	targetIndent := indent(lines[target])
+	isKey := !listItemRe.MatchString(lines[target])
+	end = target + 1
+	for j := target + 1; j < len(lines); j++ {
+		line := lines[j]
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		lineIndent := indent(line)
+		if lineIndent > targetIndent || (isKey && lineIndent == targetIndent && listItemRe.MatchString(line)) {
+			end = j + 1
+			continue
+		}
+
+		break
+	}
+
+	return i + 1, end, documentStart(lines, i), false
+}
+
+// isFirstContentLine reports whether the line with index i is the first YAML line of its document.
+func isFirstContentLine(lines []string, i int) bool {
+	for j := i - 1; j >= 0; j-- {
+		line := lines[j]
+		if separatorRe.MatchString(line) {
+			return true
+		}
+		if strings.TrimSpace(line) != "" && !isComment(line) && !templateRe.MatchString(line) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// documentStart returns the first non-empty line (starting from 1) of the document containing the line with index i,
+// documents are split the same way as rendered templates.
+func documentStart(lines []string, i int) int {
+	start := 0
+	for j := i; j >= 0; j-- {
+		if strings.Contains(lines[j], documentSeparator) {
+			start = j
+			// the rest of the separator line belongs to the document
+			if strings.TrimSpace(lines[j][strings.LastIndex(lines[j], documentSeparator)+len(documentSeparator):]) == "" {
+				start = j + 1
+			}
+			break
+		}
+	}
+
+	for j := start; j < len(lines); j++ {
+		if strings.TrimSpace(lines[j]) != "" {
+			return j + 1
+		}
+	}
+
+	return start + 1
+}
+
+func isComment(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return strings.HasPrefix(trimmed, "#") || (strings.HasPrefix(trimmed, "{{") && strings.Contains(trimmed, "/*"))
+}
+
+func indent(line string) int {
+	return len(line) - len(strings.TrimLeft(line, " "))
+}
+
+// Matches reports whether the directive suppresses the finding.
+func (d *Directive) Matches(e *errors.LintRuleError) bool {
+	if d.misplaced || !strings.EqualFold(d.Linter, e.Linter) || (d.Rule != "" && d.Rule != e.Rule && d.Rule != e.ID) {
+		return false
+	}
+
+	if d.File != e.Location.File {
+		return false
+	}
+
+	switch {
+	case e.Location.Line == 0:
+		// the position of the finding is unknown (e.g. the object is produced in a loop),
+		// object directives in the file apply to all its findings
+		return d.object
+	case e.Location.EndLine > 0:
+		// the finding relates to the whole document of a rendered object
+		return d.object && e.Location.Line <= d.end && e.Location.EndLine >= d.start
+	default:
+		return e.Location.Line >= d.start && e.Location.Line <= d.end
+	}
+}
+
+// Apply returns findings which are not suppressed by directives, directives that suppressed something are marked as used.
+func Apply(directives []*Directive, list *errors.LintRuleErrorsList) *errors.LintRuleErrorsList {
+	result := &errors.LintRuleErrorsList{}
+	for _, e := range list.GetErrors() {
+		suppressed := false
+		for _, d := range directives {
+			if d.Matches(e) {
+				d.used = true
+				suppressed = true
+			}
+		}
+
+		if !suppressed {
+			result.Add(e)
+		}
+	}
+
+	return result
+}
+
+// Check returns findings for directives without a reason, misplaced directives and directives which did not suppress any finding.
+func Check(module string, directives []*Directive) (result errors.LintRuleErrorsList) {
+	for _, d := range directives {
+		location := errors.Location{File: d.File, Line: d.Line, Column: 1}
+
+		if d.Linter == "" {
+			result.Add(newError(module, d, "Suppression must specify a linter: # dmt:ignore <linter> [rule] -- <reason>").
+				WithLocation(location))
+			continue
+		}
+
+		if d.Reason == "" {
+			result.Add(newError(module, d, "Suppression must have a reason after %q", reasonSeparator).WithLocation(location))
+		}
+
+		switch {
+		case d.used:
+		case d.misplaced:
+			result.Add(newError(module, d, "Suppression in a template must be placed before an object, it applies to the whole object").
+				WithLocation(location))
+		default:
+			result.Add(newError(module, d, "Suppression does not match any finding").WithLocation(location).
+				WithSeverity(errors.SeverityWarning))
+		}
+	}
+
+	return result
+}
+
+func newError(module string, d *Directive, template string, a ...any) *errors.LintRuleError {
+	e := errors.NewLintRuleError(ID, fmt.Sprintf("file = %s ; line = %d", d.File, d.Line), module, d.Text, template, a...).
+		WithRule(ID)
+	e.Linter = Linter
+
+	return e
+}
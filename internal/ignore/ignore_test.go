@@ -0,0 +1,148 @@
+package ignore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/moduletest"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	nocyrillic "github.com/deckhouse/dmt/pkg/linters/no-cyrillic"
+	"github.com/deckhouse/dmt/pkg/linters/probes"
+)
+
+const template = `# dmt:ignore k8s-resources vpa -- managed by an external operator
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  template:
+    spec:
+      containers:
+      # dmt:ignore container -- the image is pinned by digest
+      - name: app
+        image: app
+      - name: sidecar
+        image: sidecar
+---
+{{/* dmt:ignore rbac */}}
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: app
+  namespace: d8-system # dmt:ignore rbac placement -- legacy namespace
+`
+
+func TestParse(t *testing.T) {
+	directives := Parse("crds/app.yaml", template)
+	require.Len(t, directives, 4)
+	for _, d := range directives {
+		require.False(t, d.misplaced)
+	}
+
+	objectScope := directives[0]
+	require.Equal(t, "k8s-resources", objectScope.Linter)
+	require.Equal(t, "vpa", objectScope.Rule)
+	require.Equal(t, "managed by an external operator", objectScope.Reason)
+	require.Equal(t, 1, objectScope.start)
+	require.Equal(t, 14, objectScope.end)
+
+	keyScope := directives[1]
+	require.Equal(t, "container", keyScope.Linter)
+	require.Empty(t, keyScope.Rule)
+	require.Equal(t, 10, keyScope.start)
+	require.Equal(t, 12, keyScope.end)
+
+	templateComment := directives[2]
+	require.Equal(t, "rbac", templateComment.Linter)
+	require.Empty(t, templateComment.Reason)
+	require.Equal(t, 16, templateComment.document)
+
+	trailing := directives[3]
+	require.Equal(t, "placement", trailing.Rule)
+	require.Equal(t, 21, trailing.start)
+	require.Equal(t, 21, trailing.end)
+
+	// only object directives are allowed in templates
+	var misplaced []int
+	for _, d := range Parse("templates/app.yaml", template) {
+		if d.misplaced {
+			misplaced = append(misplaced, d.Line)
+		}
+	}
+	require.Equal(t, []int{10, 21}, misplaced)
+}
+
+const lintedTemplate = `# dmt:ignore probes -- probes are added by the operator
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: suppressed
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app
+---
+# dmt:ignore No-Cyrillic -- translated later
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: reported
+  namespace: d8-test
+  annotations:
+    description: Приложение # dmt:ignore no-cyrillic -- line directives are not allowed in templates
+spec:
+  template:
+    spec:
+      containers:
+      # dmt:ignore probes -- the key does not locate findings of the object
+      - name: app
+        image: app
+`
+
+func TestApply(t *testing.T) {
+	m := moduletest.New(t, map[string]string{"templates/app.yaml": lintedTemplate})
+
+	list := &errors.LintRuleErrorsList{}
+	for _, linter := range []interface {
+		Name() string
+		Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error)
+	}{
+		probes.New(&config.ProbesSettings{}),
+		nocyrillic.New(&config.NoCyrillicSettings{}),
+	} {
+		found, err := linter.Run(context.Background(), m)
+		require.NoError(t, err)
+		for _, e := range found.GetErrors() {
+			e.Linter = linter.Name()
+			list.Add(e)
+		}
+	}
+	require.Equal(t, 3, list.Len())
+
+	directives, err := Load(m.GetPath())
+	require.NoError(t, err)
+	require.Len(t, directives, 4)
+
+	// object directives suppress findings of the whole object, linter names are compared case-insensitively
+	result := Apply(directives, list)
+	require.Equal(t, 1, result.Len())
+	require.Equal(t, "probes", result.GetErrors()[0].Linter)
+	require.Contains(t, result.GetErrors()[0].ObjectID, "name = reported")
+
+	// line and key directives in templates are misplaced
+	findings := Check("test-module", directives)
+	require.Equal(t, 2, findings.Len())
+	for i, line := range []int{21, 26} {
+		require.Equal(t, Linter, findings.GetErrors()[i].Linter)
+		require.Equal(t, line, findings.GetErrors()[i].Location.Line)
+		require.Contains(t, findings.GetErrors()[i].Text, "must be placed before an object")
+	}
+}
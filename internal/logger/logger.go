@@ -8,7 +8,8 @@ import (
 	"os"
 )
 
-var logger *slog.Logger
+// logger discards messages until InitLogger is called, e.g. if dmt is used as a library.
+var logger = slog.New(slog.NewTextHandler(io.Discard, nil))
 
 func InitLogger(logLevel string) {
 	log.SetOutput(io.Discard)
@@ -16,7 +17,7 @@ func InitLogger(logLevel string) {
 	lvl := new(slog.LevelVar)
 	lvl.Set(slog.LevelInfo)
 
-	logger = slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{Level: lvl}))
+	logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: lvl}))
 
 	if logLevel == "DEBUG" {
 		lvl.Set(slog.LevelDebug)
@@ -0,0 +1,27 @@
+package manager
+
+import (
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/linters/container"
+	"github.com/deckhouse/dmt/pkg/linters/helm"
+	k8s_resources "github.com/deckhouse/dmt/pkg/linters/k8s-resources"
+	"github.com/deckhouse/dmt/pkg/linters/license"
+	"github.com/deckhouse/dmt/pkg/linters/monitoring"
+	no_cyrillic "github.com/deckhouse/dmt/pkg/linters/no-cyrillic"
+	"github.com/deckhouse/dmt/pkg/linters/openapi"
+	"github.com/deckhouse/dmt/pkg/linters/probes"
+	"github.com/deckhouse/dmt/pkg/linters/rbac"
+)
+
+// Built-in linters.
+func init() {
+	Register("openapi", func(cfg *config.Config) Linter { return openapi.New(&cfg.LintersSettings.OpenAPI) })
+	Register("no-cyrillic", func(cfg *config.Config) Linter { return no_cyrillic.New(&cfg.LintersSettings.NoCyrillic) })
+	Register("license", func(cfg *config.Config) Linter { return license.New(&cfg.LintersSettings.License) })
+	Register("probes", func(cfg *config.Config) Linter { return probes.New(&cfg.LintersSettings.Probes) })
+	Register("container", func(cfg *config.Config) Linter { return container.New(&cfg.LintersSettings.Container) })
+	Register("k8s-resources", func(cfg *config.Config) Linter { return k8s_resources.New(&cfg.LintersSettings.K8SResources) })
+	Register("helm", func(cfg *config.Config) Linter { return helm.New(&cfg.LintersSettings.Helm) })
+	Register("rbac", func(cfg *config.Config) Linter { return rbac.New(&cfg.LintersSettings.Rbac) })
+	Register("monitoring", func(cfg *config.Config) Linter { return monitoring.New(&cfg.LintersSettings.Monitoring) })
+}
@@ -1,14 +1,32 @@
 package manager
 
 import (
+	"context"
+
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
+// Linter is implemented by built-in linters and rule packs, other linters are wrapped by Public.
 type Linter interface {
-	Run(m *module.Module) (errors.LintRuleErrorsList, error)
+	Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error)
 	Name() string
 	Desc() string
+	Rules() []linters.Rule
 }
 
 type LinterList []Linter
+
+// Public wraps a linter registered by dmt.RegisterLinter, which gets the module as linters.Module.
+func Public(linter linters.Linter) Linter {
+	return publicLinter{Linter: linter}
+}
+
+type publicLinter struct {
+	linters.Linter
+}
+
+func (l publicLinter) Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
+	return l.Linter.Run(ctx, m)
+}
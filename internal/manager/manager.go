@@ -1,31 +1,43 @@
 package manager
 
 import (
+	"cmp"
+	"context"
+	"encoding/json"
 	"fmt"
+	"maps"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"runtime/debug"
+	"slices"
 	"strings"
-
-	k8s_resources "github.com/deckhouse/dmt/pkg/linters/k8s-resources"
-	"github.com/deckhouse/dmt/pkg/linters/monitoring"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/sourcegraph/conc/pool"
 
-	"github.com/deckhouse/dmt/internal/flags"
+	"github.com/deckhouse/dmt/internal/cache"
+	"github.com/deckhouse/dmt/internal/ignore"
 	"github.com/deckhouse/dmt/internal/logger"
 	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
-	"github.com/deckhouse/dmt/pkg/linters/container"
-	"github.com/deckhouse/dmt/pkg/linters/helm"
-	"github.com/deckhouse/dmt/pkg/linters/license"
-	no_cyrillic "github.com/deckhouse/dmt/pkg/linters/no-cyrillic"
-	"github.com/deckhouse/dmt/pkg/linters/openapi"
-	"github.com/deckhouse/dmt/pkg/linters/probes"
-	"github.com/deckhouse/dmt/pkg/linters/rbac"
+	"github.com/deckhouse/dmt/pkg/linters"
+	customrules "github.com/deckhouse/dmt/pkg/linters/custom-rules"
+	"github.com/deckhouse/dmt/pkg/linters/policy"
+	"github.com/deckhouse/dmt/pkg/rulepack"
 )
 
+// ExpiredExclusionID is the ID of findings about expired exclusions in config files, they are reported by the "dmt" linter.
+const ExpiredExclusionID = "expired-exclusion"
+
+// InternalErrorID is the ID of findings about linters which panicked or timed out on a module,
+// they are reported by the "dmt" linter.
+const InternalErrorID = "internal-error"
+
 const (
 	ChartConfigFilename = "Chart.yaml"
 	ModuleYamlFilename  = "module.yaml"
@@ -33,44 +45,70 @@ const (
 	ImagesDir           = "images"
 )
 
+// DefaultParallel is the default number of modules loading and linters running at the same time.
+const DefaultParallel = 10
+
+// Timings contains durations of phases of a run, loading of modules and linting overlap.
+type Timings struct {
+	// Discovery is the time of finding modules, loading their configs and creating linters.
+	Discovery time.Duration
+	// Loading is the time of loading and rendering all modules.
+	Loading time.Duration
+	// Linting is the time from the start of the run until all linters are finished, it includes Loading.
+	Linting time.Duration
+	// Modules contains the time of loading and rendering of every module.
+	Modules map[string]time.Duration
+	// Linters contains the total time of every linter on all modules.
+	Linters map[string]time.Duration
+}
+
 type Manager struct {
 	cfg     *config.Config
 	Linters LinterList
+	// Modules contains loaded modules in the order they are found, see LoadModules.
 	Modules []*module.Module
+	// Parallel is the number of modules loading and the number of linters running at the same time,
+	// it is DefaultParallel if it is not set.
+	Parallel int
+	// Timeout limits the run of a linter on a module, there is no limit if it is not set.
+	Timeout time.Duration
+	// Cache contains findings and rendered objects of modules linted before, unchanged modules are restored from it
+	// instead of being loaded and linted. There is no cache if it is nil.
+	Cache *cache.Cache
+	// Scenarios contains values scenarios every module is linted with in addition to generated values
+	// and combinations of its values matrix.
+	Scenarios []module.Scenario
+	Timings   Timings
+	// Cached contains names of modules restored from the cache by Run.
+	Cached []string
 
-	lintersMap map[string]Linter
+	// paths contains directories of found modules.
+	paths  []string
+	loaded bool
+	// timingsMu guards Timings and Cached while modules are loaded and linted.
+	timingsMu sync.Mutex
+
+	// linters contains linters created with settings of every config by their names.
+	linters map[*config.Config]map[string]Linter
+	// configs contains effective configs of modules by their directories,
+	// they differ if modules have their own config files.
+	configs map[string]*config.Config
 }
 
-func NewManager(dirs []string, cfg *config.Config) *Manager {
+// NewManager finds modules in the directories and creates linters with settings from their configs,
+// modules are loaded by Run or LoadModules.
+func NewManager(dirs []string, cfg *config.Config) (*Manager, error) {
+	start := time.Now()
 	m := &Manager{
-		cfg: cfg,
-	}
-
-	// fill all linters
-	m.Linters = []Linter{
-		openapi.New(&cfg.LintersSettings.OpenAPI),
-		no_cyrillic.New(&cfg.LintersSettings.NoCyrillic),
-		license.New(&cfg.LintersSettings.License),
-		probes.New(&cfg.LintersSettings.Probes),
-		container.New(&cfg.LintersSettings.Container),
-		k8s_resources.New(&cfg.LintersSettings.K8SResources),
-		helm.New(&cfg.LintersSettings.Helm),
-		rbac.New(&cfg.LintersSettings.Rbac),
-		monitoring.New(&cfg.LintersSettings.Monitoring),
-	}
-
-	m.lintersMap = make(map[string]Linter)
-	for _, linter := range m.Linters {
-		m.lintersMap[strings.ToLower(linter.Name())] = linter
+		cfg:     cfg,
+		configs: make(map[string]*config.Config),
+		linters: make(map[*config.Config]map[string]Linter),
 	}
 
-	m.Linters = make(LinterList, 0)
-	for _, linter := range m.lintersMap {
-		m.Linters = append(m.Linters, linter)
+	if err := m.initLinters(cfg); err != nil {
+		return nil, err
 	}
 
-	var paths []string
-
 	for i := range dirs {
 		dir, err := homedir.Expand(dirs[i])
 		if err != nil {
@@ -82,58 +120,723 @@ func NewManager(dirs []string, cfg *config.Config) *Manager {
 			logger.ErrorF("Error getting module paths: %v", err)
 			continue
 		}
-		paths = append(paths, result...)
+		m.paths = append(m.paths, result...)
 	}
 
-	for i := range paths {
-		moduleName := filepath.Base(paths[i])
+	for _, path := range m.paths {
+		moduleName := filepath.Base(path)
 		logger.DebugF("Found `%s` module", moduleName)
-		mdl, err := module.NewModule(paths[i])
+
+		mdlCfg, err := cfg.ForModule(path)
 		if err != nil {
-			logger.ErrorF("Cannot create module `%s`: %s", moduleName, err)
-			continue
+			return nil, err
+		}
+		if _, ok := m.linters[mdlCfg]; !ok {
+			logger.DebugF("Module `%s` uses config files %s", moduleName, mdlCfg.Files())
+			if err = m.initLinters(mdlCfg); err != nil {
+				return nil, fmt.Errorf("module %s: %w", moduleName, err)
+			}
+		}
+		m.configs[path] = mdlCfg
+	}
+
+	// linters which are enabled for any module
+	for cfg, lintersMap := range m.linters {
+		for _, linter := range enabledLinters(cfg, lintersMap) {
+			if !slices.ContainsFunc(m.Linters, func(l Linter) bool { return l.Name() == linter.Name() }) {
+				m.Linters = append(m.Linters, linter)
+			}
+		}
+	}
+	slices.SortFunc(m.Linters, func(a, b Linter) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+	m.Timings.Discovery = time.Since(start)
+
+	return m, nil
+}
+
+// KeepChanged keeps only modules affected by the changed files: files in the module directory, config files
+// of the module and images digests, paths of changed files must be absolute. It must be called before modules are loaded.
+func (m *Manager) KeepChanged(changed []string) {
+	resolved := make([]string, 0, len(changed))
+	for _, file := range changed {
+		resolved = append(resolved, realPath(file))
+	}
+
+	m.paths = slices.DeleteFunc(m.paths, func(path string) bool {
+		cfg, ok := m.configs[path]
+		if !ok {
+			cfg = m.cfg
+		}
+
+		dir := realPath(path) + string(filepath.Separator)
+		deps := []string{realPath(filepath.Join(filepath.Dir(path), module.ImagesDigestsFilename))}
+		for _, file := range cfg.Files() {
+			deps = append(deps, realPath(file))
+		}
+
+		affected := slices.ContainsFunc(resolved, func(file string) bool {
+			return strings.HasPrefix(file, dir) || slices.Contains(deps, file)
+		})
+		if !affected {
+			logger.DebugF("Module `%s` is not changed", filepath.Base(path))
+		}
+
+		return !affected
+	})
+}
+
+// realPath returns the absolute path with resolved symlinks, the file itself may not exist.
+func realPath(path string) string {
+	path, _ = filepath.Abs(path)
+	if resolved, err := filepath.EvalSymlinks(path); err == nil {
+		return resolved
+	}
+	if dir, err := filepath.EvalSymlinks(filepath.Dir(path)); err == nil {
+		return filepath.Join(dir, filepath.Base(path))
+	}
+
+	return path
+}
+
+// LoadModules loads and renders found modules if they are not loaded yet.
+func (m *Manager) LoadModules(ctx context.Context) {
+	m.loadModules(ctx, false, func(*module.Module, []*module.Module, *cache.Entry, string) {})
+}
+
+// loadModules loads and renders modules concurrently and passes every module to the callback as soon as it is loaded.
+// Modules which can't be loaded are skipped with an error in the log. Loaded modules are passed to the callback again.
+//
+// If lint is set, the module is rendered with values scenarios as well, the callback gets modules rendered with
+// scenarios which produce objects different from the generated values. If there is the cache, the callback gets
+// the cache key of the module, it is empty if the key can't be computed. A module found in the cache is restored
+// without rendering and the callback gets its entry.
+func (m *Manager) loadModules(ctx context.Context, lint bool,
+	loaded func(mdl *module.Module, scenarios []*module.Module, entry *cache.Entry, key string)) {
+	if m.loaded {
+		for _, mdl := range m.Modules {
+			loaded(mdl, nil, nil, "")
 		}
-		m.Modules = append(m.Modules, mdl)
+		return
+	}
+
+	start := time.Now()
+	modules := make([]*module.Module, len(m.paths))
+
+	var g = pool.New().WithMaxGoroutines(cmp.Or(m.Parallel, DefaultParallel))
+	for i, path := range m.paths {
+		g.Go(func() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			begin := time.Now()
+			var key string
+			if lint && m.Cache != nil {
+				var err error
+				if key, err = m.cacheKey(path); err != nil {
+					logger.DebugF("Cannot compute the cache key of `%s` module: %s", filepath.Base(path), err)
+				} else if entry, ok := m.Cache.Get(key); ok {
+					mdl := entry.Module(path)
+					m.addTiming(&m.Timings.Modules, mdl.GetName(), time.Since(begin))
+
+					modules[i] = mdl
+					loaded(mdl, nil, entry, key)
+					return
+				}
+			}
+
+			mdl, err := loadModule(path, nil)
+			if err != nil {
+				logger.ErrorF("Cannot create module `%s`: %s", filepath.Base(path), err)
+				return
+			}
+			var scenarios []*module.Module
+			if lint {
+				scenarios = m.loadScenarios(path, mdl)
+			}
+			m.addTiming(&m.Timings.Modules, mdl.GetName(), time.Since(begin))
+
+			modules[i] = mdl
+			loaded(mdl, scenarios, nil, key)
+		})
 	}
+	g.Wait()
+
+	m.Modules = slices.DeleteFunc(modules, func(mdl *module.Module) bool { return mdl == nil })
+	m.loaded = ctx.Err() == nil
+	m.Timings.Loading = time.Since(start)
 
 	logger.InfoF("Found %d modules", len(m.Modules))
+}
+
+// loadScenarios renders the module with values files of the manager and combinations of its values matrix.
+// Scenarios which can't be rendered are skipped with an error in the log, scenarios which produce the same objects
+// as the generated values are skipped, since they find nothing new.
+func (m *Manager) loadScenarios(path string, mdl *module.Module) []*module.Module {
+	matrix, err := module.MatrixScenarios(path)
+	if err != nil {
+		logger.ErrorF("Cannot read the values matrix of `%s` module: %s", mdl.GetName(), err)
+	}
+	if len(matrix) == module.MaxMatrixScenarios {
+		logger.WarnF("The values matrix of `%s` module is limited to %d combinations", mdl.GetName(), module.MaxMatrixScenarios)
+	}
+
+	var result []*module.Module
+	for _, scenario := range slices.Concat(m.Scenarios, matrix) {
+		smdl, err := loadModule(path, &scenario)
+		if err != nil {
+			logger.ErrorF("Cannot render `%s` module with values %s: %s", mdl.GetName(), scenario.Name, err)
+			continue
+		}
+
+		if sameObjects(smdl, mdl) {
+			logger.DebugF("Values %s don't change objects of `%s` module", scenario.Name, mdl.GetName())
+			continue
+		}
+		result = append(result, smdl)
+	}
 
-	return m
+	return result
 }
 
-func (m *Manager) Run() errors.LintRuleErrorsList {
-	result := errors.LintRuleErrorsList{}
+// sameObjects reports whether the modules have the same rendered objects.
+func sameObjects(a, b *module.Module) bool {
+	return maps.EqualFunc(a.GetStorage(), b.GetStorage(), func(x, y storage.StoreObject) bool {
+		return x.Hash == y.Hash && x.Path == y.Path
+	})
+}
+
+// loadModule loads the module in the directory and renders it with values of the scenario if it is set,
+// a panic while rendering the module is returned as an error.
+func loadModule(path string, scenario *module.Scenario) (mdl *module.Module, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	if scenario != nil {
+		return module.NewScenarioModule(path, *scenario)
+	}
+
+	return module.NewModule(path)
+}
+
+// cacheKey returns the cache key of the module in the directory. It depends on the dmt build, the effective config
+// of the module and contents of module files, images digests, Rego policies and rule pack executables.
+func (m *Manager) cacheKey(path string) (string, error) {
+	cfg, ok := m.configs[path]
+	if !ok {
+		cfg = m.cfg
+	}
+
+	settings, err := cfg.AsMap()
+	if err != nil {
+		return "", err
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return "", err
+	}
+	scenarios, err := json.Marshal(m.Scenarios)
+	if err != nil {
+		return "", err
+	}
+
+	key := cache.NewKey()
+	key.Add("version", []byte(cache.Version()))
+	key.Add("config", data)
+	key.Add("scenarios", scenarios)
+	if err = key.AddDir(path); err != nil {
+		return "", err
+	}
+	if err = key.AddFile(filepath.Join(filepath.Dir(path), module.ImagesDigestsFilename)); err != nil {
+		return "", err
+	}
+	for _, dir := range cfg.LintersSettings.Policy.Paths {
+		if err = key.AddDir(dir); err != nil {
+			return "", err
+		}
+	}
+	for _, pack := range cfg.RulePacks {
+		command, err := exec.LookPath(pack.Command)
+		if err != nil {
+			return "", err
+		}
+		if err = key.AddFile(command); err != nil {
+			return "", err
+		}
+	}
+
+	return key.Sum(), nil
+}
+
+// addTiming adds the duration to the timing of the name.
+func (m *Manager) addTiming(timings *map[string]time.Duration, name string, d time.Duration) {
+	m.timingsMu.Lock()
+	defer m.timingsMu.Unlock()
+
+	if *timings == nil {
+		*timings = make(map[string]time.Duration)
+	}
+	(*timings)[name] += d
+}
+
+// initLinters creates registered linters, linters of custom rules and Rego policies if they are configured
+// and linters of rule packs with settings from the config and checks selectors of the config.
+func (m *Manager) initLinters(cfg *config.Config) error {
+	lintersMap := make(map[string]Linter)
+	for name, factory := range registered() {
+		lintersMap[name] = factory(cfg)
+	}
+
+	if len(cfg.CustomRules) > 0 {
+		linter, err := customrules.New(cfg.CustomRules)
+		if err != nil {
+			return err
+		}
+		lintersMap[customrules.Name] = linter
+	}
+
+	if len(cfg.LintersSettings.Policy.Paths) > 0 {
+		linter, err := policy.New(&cfg.LintersSettings.Policy)
+		if err != nil {
+			return err
+		}
+		lintersMap[policy.Name] = linter
+	}
+
+	for _, pack := range cfg.RulePacks {
+		linter, err := rulepack.New(pack)
+		if err != nil {
+			return err
+		}
+
+		name := strings.ToLower(linter.Name())
+		if _, ok := lintersMap[name]; ok || name == ignore.Linter {
+			return fmt.Errorf("rule pack %s: linter %q already exists", pack.Command, name)
+		}
+		lintersMap[name] = linter
+	}
+
+	if err := validateSelectors(lintersMap, &cfg.Linters); err != nil {
+		return err
+	}
+	m.linters[cfg] = lintersMap
+
+	return nil
+}
+
+// enabledLinters returns linters from lintersMap which are enabled in the config.
+func enabledLinters(cfg *config.Config, lintersMap map[string]Linter) LinterList {
+	result := make(LinterList, 0)
+	for name, linter := range lintersMap {
+		if !cfg.Linters.IsLinterEnabled(name) {
+			logger.DebugF("Linter `%s` is disabled", name)
+			continue
+		}
+		// e.g. the only rule of the linter is disabled by the "<linter>/<rule>" selector
+		rules := linter.Rules()
+		if len(rules) > 0 && !slices.ContainsFunc(rules, func(rule linters.Rule) bool {
+			return cfg.Linters.IsRuleEnabled(name, rule.ID)
+		}) {
+			logger.DebugF("All rules of linter `%s` are disabled", name)
+			continue
+		}
+		result = append(result, linter)
+	}
+	slices.SortFunc(result, func(a, b Linter) int {
+		return cmp.Compare(a.Name(), b.Name())
+	})
+
+	return result
+}
+
+// ModuleConfig returns the effective config of the module.
+func (m *Manager) ModuleConfig(mdl *module.Module) *config.Config {
+	if cfg, ok := m.configs[mdl.GetPath()]; ok {
+		return cfg
+	}
+
+	return m.cfg
+}
+
+// Run loads modules and runs linters on them, linters of a module are started as soon as it is loaded.
+// If the context is canceled, linters which are not finished are abandoned and findings of finished linters are returned.
+func (m *Manager) Run(ctx context.Context) errors.LintRuleErrorsList {
+	start := time.Now()
+	result := m.runLinters(ctx)
+	m.Timings.Linting = time.Since(start)
+
+	result = m.applyDirectives(result)
+	result.Merge(m.expiredExclusions())
+	m.applySeverity(&result)
+
+	return result
+}
+
+// runLinters runs linters on modules, every module is linted by linters with settings from its config.
+func (m *Manager) runLinters(ctx context.Context) errors.LintRuleErrorsList {
+	// modules with the same config share linters
+	lintersByConfig := make(map[*config.Config]LinterList)
+	for cfg, lintersMap := range m.linters {
+		lintersByConfig[cfg] = enabledLinters(cfg, lintersMap)
+	}
 
 	var ch = make(chan errors.LintRuleErrorsList)
 	go func() {
-		var g = pool.New().WithMaxGoroutines(flags.LintersLimit)
-		for i := range m.Modules {
-			logger.InfoF("Run linters for `%s` module", m.Modules[i].GetName())
-			for j := range m.Linters {
-				g.Go(func() {
-					logger.DebugF("Running linter `%s` on module `%s`", m.Linters[j].Name(), m.Modules[i].GetName())
-					errs, err := m.Linters[j].Run(m.Modules[i])
-					if err != nil {
-						logger.ErrorF("Error running linter `%s`: %s\n", m.Linters[j].Name(), err)
-						return
-					}
-					if errs.ConvertToError() != nil {
-						ch <- errs
-					}
-				})
+		var g = pool.New().WithMaxGoroutines(cmp.Or(m.Parallel, DefaultParallel))
+		m.loadModules(ctx, true, func(mdl *module.Module, scenarios []*module.Module, entry *cache.Entry, key string) {
+			if entry != nil {
+				logger.InfoF("Findings of `%s` module are restored from the cache", mdl.GetName())
+				m.timingsMu.Lock()
+				m.Cached = append(m.Cached, mdl.GetName())
+				m.timingsMu.Unlock()
+				ch <- entry.Errors()
+				return
 			}
-		}
+
+			logger.InfoF("Run linters for `%s` module", mdl.GetName())
+			cfg := m.ModuleConfig(mdl)
+			// the module is rendered with every scenario and all of them are linted before the module is cached
+			variants := append([]*module.Module{mdl}, scenarios...)
+			run := &moduleRun{pending: len(variants) * len(lintersByConfig[cfg])}
+			store := func(findings errors.LintRuleErrorsList, complete bool) {
+				if !run.done(findings, complete) || key == "" || ctx.Err() != nil {
+					return
+				}
+				if err := m.Cache.Put(key, cache.NewEntry(mdl, mergeScenarios(run.findings))); err != nil {
+					logger.WarnF("Cannot cache findings of `%s` module: %s", mdl.GetName(), err)
+				}
+			}
+			if run.pending == 0 {
+				store(errors.LintRuleErrorsList{}, true)
+			}
+
+			for _, variant := range variants {
+				for _, linter := range lintersByConfig[cfg] {
+					g.Go(func() {
+						if ctx.Err() != nil {
+							store(errors.LintRuleErrorsList{}, false)
+							return
+						}
+
+						logger.DebugF("Running linter `%s` on module `%s`", linter.Name(), variant.GetName())
+						begin := time.Now()
+						// linters skip disabled rules, findings are filtered below for linters which run them anyway
+						linterCtx := linters.WithRuleFilter(ctx, func(rule string) bool {
+							return cfg.Linters.IsRuleEnabled(strings.ToLower(linter.Name()), rule)
+						})
+						errs, internal, err := m.runLinter(linterCtx, linter, variant)
+						m.addTiming(&m.Timings.Linters, linter.Name(), time.Since(begin))
+						if internal != nil {
+							// internal errors may be caused by the environment, so the module is not cached
+							store(errors.LintRuleErrorsList{}, false)
+							if cfg.Linters.IsRuleEnabled(ignore.Linter, InternalErrorID) {
+								list := errors.LintRuleErrorsList{}
+								list.Add(tagScenario(internal, variant))
+								ch <- list
+							}
+							return
+						}
+						if err != nil {
+							store(errors.LintRuleErrorsList{}, false)
+							if ctx.Err() == nil {
+								logger.ErrorF("Error running linter `%s`: %s\n", linter.Name(), err)
+							}
+							return
+						}
+						enabled := errors.LintRuleErrorsList{}
+						for _, e := range errs.GetErrors() {
+							e.Linter = linter.Name()
+							e.Module = variant.GetName()
+							if cfg.Linters.IsRuleEnabled(strings.ToLower(e.Linter), e.Rule) {
+								enabled.Add(tagScenario(e, variant))
+							}
+						}
+						// the module is stored before findings are changed by directives and severity overrides
+						store(enabled, true)
+						if enabled.Len() > 0 {
+							ch <- enabled
+						}
+					})
+				}
+			}
+		})
 		g.Wait()
 		close(ch)
 	}()
 
+	// findings of scenarios are merged when all modules are linted, since findings of a module arrive in any order
+	var found []*errors.LintRuleError
 	for er := range ch {
-		result.Merge(er)
+		found = append(found, er.GetErrors()...)
+	}
+
+	return mergeScenarios(found)
+}
+
+// tagScenario sets the scenario of the module to the finding if the module is rendered with a values scenario.
+func tagScenario(e *errors.LintRuleError, mdl *module.Module) *errors.LintRuleError {
+	if scenario := mdl.GetScenario(); scenario != "" {
+		e.Scenarios = []string{scenario}
+	}
+
+	return e
+}
+
+// mergeScenarios removes duplicate findings like errors.LintRuleErrorsList does, findings of values scenarios
+// are merged. A finding which is found with generated values is reported without scenarios, other findings
+// are reported once with names of all scenarios they are found with. The findings are not changed.
+func mergeScenarios(found []*errors.LintRuleError) errors.LintRuleErrorsList {
+	result := errors.LintRuleErrorsList{}
+	var generated, tagged []*errors.LintRuleError
+	for _, e := range found {
+		if len(e.Scenarios) == 0 {
+			generated = append(generated, e)
+			result.Add(e)
+		}
+	}
+
+	for _, e := range found {
+		if len(e.Scenarios) == 0 || slices.ContainsFunc(generated, e.EqualsTo) {
+			continue
+		}
+
+		i := slices.IndexFunc(tagged, e.EqualsTo)
+		if i < 0 {
+			merged := *e
+			merged.Scenarios = slices.Clone(e.Scenarios)
+			tagged = append(tagged, &merged)
+			continue
+		}
+		for _, scenario := range e.Scenarios {
+			if !slices.Contains(tagged[i].Scenarios, scenario) {
+				tagged[i].Scenarios = append(tagged[i].Scenarios, scenario)
+			}
+		}
+		slices.Sort(tagged[i].Scenarios)
+	}
+	for _, e := range tagged {
+		result.Add(e)
+	}
+
+	return result
+}
+
+// moduleRun collects findings of linters of a module to store them in the cache when all linters are finished.
+type moduleRun struct {
+	mu       sync.Mutex
+	pending  int
+	findings []*errors.LintRuleError
+	// failed is set if any linter didn't complete, findings of such a module are not cached
+	failed bool
+}
+
+// done adds findings of a finished linter, it returns true if it was the last linter and all linters completed.
+func (r *moduleRun) done(findings errors.LintRuleErrorsList, complete bool) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.findings = append(r.findings, findings.GetErrors()...)
+	r.failed = r.failed || !complete
+	r.pending--
+
+	return r.pending <= 0 && !r.failed
+}
+
+// runLinter runs the linter on the module in a separate goroutine and waits for it until the timeout.
+// A panic of the linter and the timeout are returned as an internal error finding.
+// If the context is canceled, the linter is abandoned and the context error is returned.
+func (m *Manager) runLinter(ctx context.Context, linter Linter, mdl *module.Module) (
+	errors.LintRuleErrorsList, *errors.LintRuleError, error) {
+	runCtx := ctx
+	if m.Timeout > 0 {
+		var cancel context.CancelFunc
+		runCtx, cancel = context.WithTimeout(ctx, m.Timeout)
+		defer cancel()
+	}
+
+	type outcome struct {
+		errs      errors.LintRuleErrorsList
+		err       error
+		recovered any
+	}
+
+	done := make(chan outcome, 1)
+	go func() {
+		defer func() {
+			if r := recover(); r != nil {
+				logger.DebugF("Linter `%s` panicked on module `%s`: %v\n%s", linter.Name(), mdl.GetName(), r, debug.Stack())
+				done <- outcome{recovered: r}
+			}
+		}()
+
+		errs, err := linter.Run(runCtx, mdl)
+		done <- outcome{errs: errs, err: err}
+	}()
+
+	select {
+	case o := <-done:
+		if o.recovered != nil {
+			return errors.LintRuleErrorsList{}, internalError(linter, mdl, "Linter panicked: %v", o.recovered), nil
+		}
+
+		return o.errs, nil, o.err
+	case <-runCtx.Done():
+		if err := ctx.Err(); err != nil {
+			return errors.LintRuleErrorsList{}, nil, err
+		}
+
+		return errors.LintRuleErrorsList{}, internalError(linter, mdl, "Linter timed out after %s", m.Timeout), nil
+	}
+}
+
+// internalError returns the finding about a failure of the linter on the module.
+func internalError(linter Linter, mdl *module.Module, template string, a ...any) *errors.LintRuleError {
+	e := errors.NewLintRuleError(InternalErrorID, linter.Name(), mdl.GetName(), nil, template, a...).WithRule(InternalErrorID)
+	e.Linter = ignore.Linter
+
+	return e
+}
+
+// applySeverity sets default severities of rules to findings and applies severity overrides from configs of modules.
+func (m *Manager) applySeverity(list *errors.LintRuleErrorsList) {
+	defaults := make(map[string]map[string]errors.Severity)
+	for _, lintersMap := range m.linters {
+		for name, linter := range lintersMap {
+			defaults[name] = make(map[string]errors.Severity)
+			for _, rule := range linter.Rules() {
+				defaults[name][rule.ID] = rule.Severity
+			}
+		}
+	}
+
+	configs := make(map[string]*config.Config)
+	for _, mdl := range m.Modules {
+		configs[mdl.GetName()] = m.ModuleConfig(mdl)
+	}
+
+	for _, e := range list.GetErrors() {
+		if e.Severity == "" {
+			e.Severity = cmp.Or(defaults[strings.ToLower(e.Linter)][e.Rule], errors.SeverityError)
+		}
+
+		cfg, ok := configs[e.Module]
+		if !ok {
+			cfg = m.cfg
+		}
+		cfg.ApplySeverity(e)
+	}
+}
+
+// expiredExclusions reports exclusions in config files which are expired, they no longer suppress findings.
+func (m *Manager) expiredExclusions() errors.LintRuleErrorsList {
+	result := errors.LintRuleErrorsList{}
+	if !m.cfg.Linters.IsRuleEnabled(ignore.Linter, ExpiredExclusionID) {
+		return result
+	}
+
+	for _, e := range m.cfg.ExpiredExclusions() {
+		text := fmt.Sprintf("Exclusion expired on %s, the check is applied again", e.Until.Format(time.DateOnly))
+		var details []string
+		if e.Reason != "" {
+			details = append(details, "reason: "+e.Reason)
+		}
+		if e.Issue != "" {
+			details = append(details, "issue: "+e.Issue)
+		}
+		if len(details) > 0 {
+			text += " (" + strings.Join(details, ", ") + ")"
+		}
+
+		finding := errors.NewLintRuleError(ExpiredExclusionID, e.Exclusion.String(), "", e.Value, "%s", text).
+			WithRule(ExpiredExclusionID).WithFilePath(e.File)
+		finding.Linter = ignore.Linter
+		result.Add(finding)
+	}
+
+	return result
+}
+
+// applyDirectives drops findings suppressed by "dmt:ignore" directives in module sources
+// and reports directives without a reason or without a matching finding.
+func (m *Manager) applyDirectives(list errors.LintRuleErrorsList) errors.LintRuleErrorsList {
+	byModule := make(map[string]*errors.LintRuleErrorsList)
+	for _, e := range list.GetErrors() {
+		if byModule[e.Module] == nil {
+			byModule[e.Module] = &errors.LintRuleErrorsList{}
+		}
+		byModule[e.Module].Add(e)
+	}
+
+	result := errors.LintRuleErrorsList{}
+	for _, mdl := range m.Modules {
+		findings := byModule[mdl.GetName()]
+		if findings == nil {
+			findings = &errors.LintRuleErrorsList{}
+		}
+		delete(byModule, mdl.GetName())
+
+		directives, err := ignore.Load(mdl.GetPath())
+		if err != nil {
+			logger.ErrorF("Cannot load suppressions of `%s` module: %s", mdl.GetName(), err)
+			result.Merge(*findings)
+			continue
+		}
+
+		// suppressions of disabled linters and rules are not checked
+		cfg := m.ModuleConfig(mdl)
+		directives = slices.DeleteFunc(directives, func(d *ignore.Directive) bool {
+			return d.Linter != "" && !cfg.Linters.IsRuleEnabled(d.Linter, d.Rule)
+		})
+
+		result.Merge(*ignore.Apply(directives, findings))
+		if cfg.Linters.IsRuleEnabled(ignore.Linter, ignore.ID) {
+			result.Merge(ignore.Check(mdl.GetName(), directives))
+		}
+	}
+
+	for _, findings := range byModule {
+		result.Merge(*findings)
 	}
 
 	return result
 }
 
+// validateSelectors checks that enabled and disabled linters and rules exist.
+func validateSelectors(lintersMap map[string]Linter, cfg *config.LintersConfig) error {
+	for _, selector := range slices.Concat(cfg.Enable, cfg.Disable) {
+		name, rule := config.SplitSelector(selector)
+		if name == ignore.Linter && (rule == "" || rule == ignore.ID || rule == ExpiredExclusionID || rule == InternalErrorID) {
+			continue
+		}
+
+		linter, ok := lintersMap[name]
+		if !ok {
+			return fmt.Errorf("unknown linter %q in %q, available linters: %s",
+				name, selector, strings.Join(slices.Sorted(maps.Keys(lintersMap)), ", "))
+		}
+
+		if rule == "" {
+			continue
+		}
+
+		if !slices.ContainsFunc(linter.Rules(), func(r linters.Rule) bool { return r.ID == rule }) {
+			var ids []string
+			for _, r := range linter.Rules() {
+				ids = append(ids, r.ID)
+			}
+
+			return fmt.Errorf("unknown rule %q of the linter %q, available rules: %s", rule, name, strings.Join(ids, ", "))
+		}
+	}
+
+	return nil
+}
+
 func isExistsOnFilesystem(parts ...string) bool {
 	_, err := os.Stat(filepath.Join(parts...))
 	return err == nil
@@ -0,0 +1,124 @@
+package manager
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/ignore"
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+type testLinter struct {
+	run func(ctx context.Context) (errors.LintRuleErrorsList, error)
+}
+
+func (l *testLinter) Run(ctx context.Context, _ *module.Module) (errors.LintRuleErrorsList, error) {
+	return l.run(ctx)
+}
+
+func (*testLinter) Name() string          { return "test" }
+func (*testLinter) Desc() string          { return "" }
+func (*testLinter) Rules() []linters.Rule { return nil }
+
+func TestManager_runLinter(t *testing.T) {
+	m := &Manager{Timeout: 50 * time.Millisecond}
+	mdl := new(module.Module)
+
+	t.Run("findings", func(t *testing.T) {
+		found := errors.LintRuleErrorsList{}
+		found.Add(errors.NewLintRuleError("test", "object", "", nil, "finding"))
+
+		errs, internal, err := m.runLinter(context.Background(), &testLinter{run: func(context.Context) (errors.LintRuleErrorsList, error) {
+			return found, nil
+		}}, mdl)
+		require.NoError(t, err)
+		require.Nil(t, internal)
+		require.Equal(t, found, errs)
+	})
+
+	t.Run("panic", func(t *testing.T) {
+		errs, internal, err := m.runLinter(context.Background(), &testLinter{run: func(context.Context) (errors.LintRuleErrorsList, error) {
+			var object map[string]any
+			object["kind"] = "Role"
+
+			return errors.LintRuleErrorsList{}, nil
+		}}, mdl)
+		require.NoError(t, err)
+		require.Zero(t, errs.Len())
+		require.NotNil(t, internal)
+		require.Equal(t, ignore.Linter, internal.Linter)
+		require.Equal(t, InternalErrorID, internal.Rule)
+		require.Equal(t, "test", internal.ObjectID)
+		require.Equal(t, "Linter panicked: assignment to entry in nil map", internal.Text)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		block := make(chan struct{})
+		defer close(block)
+
+		_, internal, err := m.runLinter(context.Background(), &testLinter{run: func(context.Context) (errors.LintRuleErrorsList, error) {
+			<-block // ignores the context
+
+			return errors.LintRuleErrorsList{}, nil
+		}}, mdl)
+		require.NoError(t, err)
+		require.NotNil(t, internal)
+		require.Equal(t, "Linter timed out after 50ms", internal.Text)
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, internal, err := m.runLinter(ctx, &testLinter{run: func(ctx context.Context) (errors.LintRuleErrorsList, error) {
+			<-ctx.Done()
+
+			return errors.LintRuleErrorsList{}, ctx.Err()
+		}}, mdl)
+		require.ErrorIs(t, err, context.Canceled)
+		require.Nil(t, internal)
+	})
+}
+
+func TestManager_applySeverity(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "dmt.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("severity-overrides:\n  - severity: warning\n    rules: [k8s-resources/vpa]\n"), 0o600))
+
+	cfg, err := config.NewDefault([]string{dir}, config.LoaderOptions{Config: path})
+	require.NoError(t, err)
+	m, err := NewManager(nil, cfg)
+	require.NoError(t, err)
+
+	finding := func(linter, rule string) *errors.LintRuleError {
+		e := errors.NewLintRuleError(rule, "object", "module", nil, "finding").WithRule(rule)
+		e.Linter = linter
+
+		return e
+	}
+
+	list := &errors.LintRuleErrorsList{}
+	list.Add(finding("k8s-resources", "vpa"))
+	list.Add(finding("k8s-resources", "service-target-port"))
+	list.Add(finding("k8s-resources", "api-version"))
+	list.Add(finding("no-cyrillic", "cyrillic-letters"))
+	list.Add(finding("container", "ports").WithSeverity(errors.SeverityInfo))
+
+	// built-in rules report errors unless they are overridden, severities set by linters are kept
+	m.applySeverity(list)
+	var severities []errors.Severity
+	for _, e := range list.GetErrors() {
+		severities = append(severities, e.Severity)
+	}
+	require.Equal(t, []errors.Severity{
+		errors.SeverityWarning, errors.SeverityError, errors.SeverityError, errors.SeverityError, errors.SeverityInfo,
+	}, severities)
+}
@@ -0,0 +1,37 @@
+package manager
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/deckhouse/dmt/pkg/config"
+)
+
+// Factory creates a linter with settings from the config.
+type Factory func(cfg *config.Config) Linter
+
+var (
+	registryMu sync.RWMutex
+	registry   = make(map[string]Factory)
+)
+
+// Register adds the linter to all runs, the name is the lower-case name of the linter.
+// It panics if a linter with the name is already registered.
+func Register(name string, factory Factory) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	if _, ok := registry[name]; ok {
+		panic(fmt.Sprintf("linter %q is already registered", name))
+	}
+	registry[name] = factory
+}
+
+// registered returns factories of registered linters by their names.
+func registered() map[string]Factory {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	return maps.Clone(registry)
+}
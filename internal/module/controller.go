@@ -1,24 +1,20 @@
 package module
 
 import (
-	"bufio"
-	"bytes"
 	"fmt"
+	"maps"
+	"path"
 	"strings"
-	"sync"
 
 	"github.com/mitchellh/hashstructure/v2"
 	"gopkg.in/yaml.v3"
+	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chartutil"
 
 	"github.com/deckhouse/dmt/internal/helm"
 	"github.com/deckhouse/dmt/internal/storage"
 )
 
-var (
-	renderedTemplatesHash = sync.Map{}
-)
-
 func RunRender(m *Module, values chartutil.Values, objectStore *storage.UnstructuredObjectStore) error {
 	var renderer helm.Renderer
 	renderer.Name = m.GetName()
@@ -35,21 +31,26 @@ func RunRender(m *Module, values chartutil.Values, objectStore *storage.Unstruct
 		return fmt.Errorf("helm chart render: %w", err)
 	}
 
-	if _, ok := renderedTemplatesHash.Load(hash); ok {
+	// the same templates rendered with other values are linted once
+	if !objectStore.MarkRendered(hash) {
 		return nil
 	}
 
-	defer renderedTemplatesHash.Store(hash, struct{}{})
-
-	var docBytes []byte
+	sources := templateSources(m.GetChart())
 
 	for path, bigFile := range files {
-		scanner := bufio.NewScanner(strings.NewReader(bigFile))
-		scanner.Split(SplitAt("---"))
+		docs := splitDocuments(bigFile)
 
-		for scanner.Scan() {
+		// documents of the rendered template can be matched with the source only if the template
+		// does not produce documents dynamically, otherwise objects of the template have no lines
+		sourceDocs := splitDocuments(sources[path])
+		if len(sourceDocs) != len(docs) {
+			sourceDocs = nil
+		}
+
+		for i, doc := range docs {
 			var node map[string]any
-			docBytes = scanner.Bytes()
+			docBytes := []byte(doc.content)
 
 			err = yaml.Unmarshal(docBytes, &node)
 			if err != nil {
@@ -60,7 +61,12 @@ func RunRender(m *Module, values chartutil.Values, objectStore *storage.Unstruct
 				continue
 			}
 
-			err = objectStore.Put(path, node, docBytes)
+			var line, endLine int
+			if sourceDocs != nil {
+				line, endLine = sourceDocs[i].line, sourceDocs[i].endLine
+			}
+
+			err = objectStore.Put(path, node, docBytes, i, line, endLine)
 			if err != nil {
 				return fmt.Errorf("helm chart object already exists: %w", err)
 			}
@@ -70,27 +76,63 @@ func RunRender(m *Module, values chartutil.Values, objectStore *storage.Unstruct
 	return nil
 }
 
-func SplitAt(substring string) func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
-		// Return nothing if at end of file and no data passed
-		if atEOF && len(data) == 0 {
-			return 0, nil, nil
-		}
+// templateSources returns contents of the chart templates (including templates of subcharts)
+// by the paths used in the rendered files.
+func templateSources(ch *chart.Chart) map[string]string {
+	sources := make(map[string]string)
+	if ch == nil {
+		return sources
+	}
 
-		// Find the index of the input of the separator substring
-		if i := bytes.Index(data, []byte(substring)); i >= 0 {
-			return i + len(substring), data[0:i], nil
-		}
+	for _, tpl := range ch.Templates {
+		sources[path.Join(ch.ChartFullPath(), tpl.Name)] = string(tpl.Data)
+	}
 
-		// If at end of file with data return the data
-		if atEOF {
-			return len(data), data, nil
-		}
+	for _, dep := range ch.Dependencies() {
+		maps.Copy(sources, templateSources(dep))
+	}
+
+	return sources
+}
 
-		return
+type document struct {
+	content string
+	// line is the first non-empty line of the document in the file
+	line int
+	// endLine is the last non-empty line of the document in the file
+	endLine int
+}
+
+// splitDocuments splits the file into YAML documents by "---" separators.
+func splitDocuments(content string) []document {
+	if content == "" {
+		return nil
 	}
+
+	var (
+		docs   []document
+		offset int
+	)
+	for _, part := range strings.Split(content, documentSeparator) {
+		start := offset + len(part) - len(strings.TrimLeft(part, " \t\r\n"))
+		end := max(offset+len(strings.TrimRight(part, " \t\r\n")), start)
+		docs = append(docs, document{
+			content: part,
+			line:    strings.Count(content[:start], "\n") + 1,
+			endLine: strings.Count(content[:end], "\n") + 1,
+		})
+		offset += len(part) + len(documentSeparator)
+	}
+
+	// the file ends with a separator, there is no document after it
+	if strings.HasSuffix(content, documentSeparator) {
+		docs = docs[:len(docs)-1]
+	}
+
+	return docs
 }
 
 const (
 	manifestErrorMessage = `manifest unmarshal: %v`
+	documentSeparator    = "---"
 )
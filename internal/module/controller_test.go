@@ -0,0 +1,122 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func Test_splitDocuments(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		// want contains the first and the last line of every document
+		want [][2]int
+	}{
+		{
+			name:    "empty file",
+			content: "",
+			want:    nil,
+		},
+		{
+			name:    "single document",
+			content: "apiVersion: v1\nkind: ConfigMap\n",
+			want:    [][2]int{{1, 2}},
+		},
+		{
+			name:    "leading separator",
+			content: "---\napiVersion: v1\nkind: ConfigMap\n---\n\napiVersion: v1\nkind: Secret\n",
+			want:    [][2]int{{1, 1}, {2, 3}, {6, 7}},
+		},
+		{
+			name:    "trailing separator",
+			content: "{{- if .Values.enabled }}\n---\napiVersion: v1\nkind: ConfigMap\n---",
+			want:    [][2]int{{1, 1}, {3, 4}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got [][2]int
+			for _, doc := range splitDocuments(tt.content) {
+				got = append(got, [2]int{doc.line, doc.endLine})
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewModule_sameTemplates(t *testing.T) {
+	// modules with the same templates must have their own objects
+	for _, name := range []string{"module-a", "module-b"} {
+		dir := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0o755))
+		require.NoError(t, os.MkdirAll(filepath.Join(dir, "openapi"), 0o755))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi", "values.yaml"), []byte("type: object\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, ChartConfigFilename), []byte("name: module\nversion: 0.1.0\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "cm.yaml"),
+			[]byte("apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n"), 0o600))
+
+		m, err := NewModule(dir)
+		require.NoError(t, err)
+		require.Len(t, m.GetStorage(), 1)
+	}
+}
+
+func TestRenderModule_overrides(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "test-module")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "templates"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "openapi"), 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "openapi", "values.yaml"), []byte(`type: object
+properties:
+  replicas:
+    type: integer
+    default: 1
+  mode:
+    type: string
+    default: simple
+`), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ChartConfigFilename), []byte("name: test-module\nversion: 0.1.0\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "templates", "cm.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: config
+data:
+  replicas: {{ .Values.testModule.replicas | quote }}
+  mode: {{ .Values.testModule.mode | default "none" | quote }}
+`), 0o600))
+
+	render := func(overrides map[string]any) (map[string]any, map[string]any) {
+		m, values, err := RenderModule(dir, overrides)
+		require.NoError(t, err)
+		require.Len(t, m.GetStorage(), 1)
+
+		for _, object := range m.GetStorage() {
+			data, _ := object.Unstructured.Object["data"].(map[string]any)
+			return values["Values"].(map[string]any)["testModule"].(map[string]any), data
+		}
+
+		return nil, nil
+	}
+
+	values, data := render(nil)
+	require.Equal(t, map[string]any{"replicas": "1", "mode": "simple"}, data)
+	require.EqualValues(t, 1, values["replicas"])
+
+	// later files override earlier ones, null removes the value
+	first := filepath.Join(t.TempDir(), "first.yaml")
+	second := filepath.Join(t.TempDir(), "second.yaml")
+	require.NoError(t, os.WriteFile(first, []byte("testModule:\n  replicas: 2\n  mode: ha\n"), 0o600))
+	require.NoError(t, os.WriteFile(second, []byte("testModule:\n  replicas: 3\n  mode: null\n"), 0o600))
+	overrides, err := ReadValuesFiles([]string{first, second})
+	require.NoError(t, err)
+
+	values, data = render(overrides)
+	require.Equal(t, map[string]any{"replicas": "3", "mode": "none"}, data)
+	require.NotContains(t, values, "mode")
+
+	// overrides are not changed, so they can be applied to other modules
+	require.Equal(t, map[string]any{"testModule": map[string]any{"replicas": float64(3), "mode": nil}}, overrides)
+}
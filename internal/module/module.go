@@ -6,9 +6,11 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/mohae/deepcopy"
 	"gopkg.in/yaml.v3"
 	"helm.sh/helm/v3/pkg/chart"
 	"helm.sh/helm/v3/pkg/chart/loader"
+	"helm.sh/helm/v3/pkg/chartutil"
 
 	"github.com/deckhouse/dmt/internal/storage"
 )
@@ -24,6 +26,8 @@ type Module struct {
 	path        string
 	chart       *chart.Chart
 	objectStore *storage.UnstructuredObjectStore
+	// scenario is the name of the values scenario the module is rendered with
+	scenario string
 }
 
 type ModuleList []*Module
@@ -54,6 +58,14 @@ func (m *Module) GetPath() string {
 	return m.path
 }
 
+// GetScenario returns the name of the values scenario the module is rendered with, it is empty for generated values.
+func (m *Module) GetScenario() string {
+	if m == nil {
+		return ""
+	}
+	return m.scenario
+}
+
 func (m *Module) GetChart() *chart.Chart {
 	if m == nil {
 		return nil
@@ -84,9 +96,17 @@ func (m *Module) GetStorage() map[storage.ResourceIndex]storage.StoreObject {
 }
 
 func NewModule(path string) (*Module, error) {
+	module, _, err := RenderModule(path, nil)
+
+	return module, err
+}
+
+// RenderModule loads the module in the directory and renders it with values generated from its OpenAPI schemas,
+// the overrides are merged into the generated values. It returns the module with rendered objects and the values.
+func RenderModule(path string, overrides map[string]any) (*Module, chartutil.Values, error) {
 	name, err := getModuleName(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	module := &Module{
@@ -97,30 +117,89 @@ func NewModule(path string) (*Module, error) {
 
 	err = checkHelmChart(name, path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	ch, err := loader.Load(path)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	module.chart = ch
 
 	values, err := ComposeValuesFromSchemas(module)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
+	if overrides != nil {
+		if values, err = applyOverrides(module, values, overrides); err != nil {
+			return nil, nil, err
+		}
+	}
+
 	objectStore := storage.NewUnstructuredObjectStore()
 	err = RunRender(module, values, objectStore)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	module.objectStore = objectStore
 
+	return module, values, nil
+}
+
+// NewScenarioModule loads the module in the directory and renders it with values of the scenario.
+func NewScenarioModule(path string, scenario Scenario) (*Module, error) {
+	module, _, err := RenderModule(path, scenario.Values)
+	if err != nil {
+		return nil, err
+	}
+	module.scenario = scenario.Name
+
 	return module, nil
 }
 
+// ReadValuesFiles reads and merges values files, values of later files override values of earlier ones.
+// Null values are kept, they remove generated values when overrides are applied.
+func ReadValuesFiles(paths []string) (map[string]any, error) {
+	result := make(map[string]any)
+	for _, path := range paths {
+		values, err := chartutil.ReadValuesFile(path)
+		if err != nil {
+			return nil, err
+		}
+		result = chartutil.MergeTables(values.AsMap(), result)
+	}
+
+	return result, nil
+}
+
+// applyOverrides merges the overrides into values of the module, a null override removes the value.
+// The overrides are copied, so they can be applied to several modules.
+func applyOverrides(m *Module, values chartutil.Values, overrides map[string]any) (chartutil.Values, error) {
+	if values == nil {
+		var err error
+		if values, err = helmFormatModuleImages(m, make(map[string]any)); err != nil {
+			return nil, err
+		}
+	}
+
+	raw, _ := values["Values"].(map[string]any)
+	values["Values"] = chartutil.CoalesceTables(deepcopy.Copy(overrides).(map[string]any), raw)
+
+	return values, nil
+}
+
+// NewModuleFromStore returns the module with already rendered objects, e.g. restored from the cache.
+// The chart of the module is not loaded.
+func NewModuleFromStore(name, namespace, path string, store *storage.UnstructuredObjectStore) *Module {
+	return &Module{
+		name:        name,
+		namespace:   namespace,
+		path:        path,
+		objectStore: store,
+	}
+}
+
 func getModuleName(path string) (name string, err error) {
 	stat, err := os.Stat(filepath.Join(path, ChartConfigFilename))
 	if err != nil {
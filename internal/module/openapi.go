@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 
 	"dario.cat/mergo"
 	"github.com/go-openapi/spec"
@@ -21,9 +22,8 @@ const (
 	ObjectKey   = "object"
 )
 
-const (
-	imageDigestfile string = "images_digests.json"
-)
+// ImagesDigestsFilename is the file with digests of images of modules, it is in the parent directory of modules.
+const ImagesDigestsFilename = "images_digests.json"
 
 func applyDigests(digests, values map[string]any) {
 	obj := map[string]any{
@@ -38,10 +38,9 @@ func applyDigests(digests, values map[string]any) {
 }
 
 func helmFormatModuleImages(m *Module, rawValues map[string]any) (chartutil.Values, error) {
-	caps := chartutil.DefaultCapabilities
-	vers := []string(caps.APIVersions)
-	vers = append(vers, "autoscaling.k8s.io/v1/VerticalPodAutoscaler")
-	caps.APIVersions = vers
+	// the default capabilities are shared by all modules, they are copied to add the VPA API
+	caps := *chartutil.DefaultCapabilities
+	caps.APIVersions = append(slices.Clone(caps.APIVersions), "autoscaling.k8s.io/v1/VerticalPodAutoscaler")
 
 	digests, err := GetModulesImagesDigests(m.GetPath())
 	if err != nil {
@@ -51,7 +50,7 @@ func helmFormatModuleImages(m *Module, rawValues map[string]any) (chartutil.Valu
 	applyDigests(digests, rawValues)
 	top := map[string]any{
 		"Chart":        m.GetMetadata(),
-		"Capabilities": caps,
+		"Capabilities": &caps,
 		"Release": map[string]any{
 			"Name":      m.GetName(),
 			"Namespace": m.GetNamespace(),
@@ -71,7 +70,7 @@ func GetModulesImagesDigests(modulePath string) (modulesDigests map[string]any,
 		search bool
 	)
 
-	if fi, errs := os.Stat(filepath.Join(filepath.Dir(modulePath), imageDigestfile)); errs != nil || fi.Size() == 0 {
+	if fi, errs := os.Stat(filepath.Join(filepath.Dir(modulePath), ImagesDigestsFilename)); errs != nil || fi.Size() == 0 {
 		search = true
 	}
 
@@ -90,7 +89,7 @@ func GetModulesImagesDigests(modulePath string) (modulesDigests map[string]any,
 func getModulesImagesDigestsFromLocalPath(modulePath string) (map[string]any, error) {
 	var digests map[string]any
 
-	imageDigestsRaw, err := os.ReadFile(filepath.Join(filepath.Dir(modulePath), imageDigestfile))
+	imageDigestsRaw, err := os.ReadFile(filepath.Join(filepath.Dir(modulePath), ImagesDigestsFilename))
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,121 @@
+package module
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"helm.sh/helm/v3/pkg/chartutil"
+)
+
+const (
+	// ValuesMatrixFilename is the file of the module with values scenarios, see MatrixScenarios.
+	ValuesMatrixFilename = "values_matrix_test.yaml"
+	// constantChoicesKey marks alternatives of a value in the values matrix.
+	constantChoicesKey = "__ConstantChoices__"
+	// MaxMatrixScenarios limits the number of combinations of the values matrix.
+	MaxMatrixScenarios = 100
+)
+
+// Scenario is a set of values which are merged into values generated from OpenAPI schemas to render the module,
+// so templates are checked with values which are not generated, e.g. in the HA mode.
+type Scenario struct {
+	Name   string
+	Values map[string]any
+}
+
+// ReadScenarios reads values files, every file is a scenario named by its path.
+func ReadScenarios(paths []string) ([]Scenario, error) {
+	scenarios := make([]Scenario, 0, len(paths))
+	for _, path := range paths {
+		values, err := chartutil.ReadValuesFile(path)
+		if err != nil {
+			return nil, err
+		}
+		scenarios = append(scenarios, Scenario{Name: path, Values: values.AsMap()})
+	}
+
+	return scenarios, nil
+}
+
+// MatrixScenarios returns combinations of values from the values matrix of the module, it returns nil if there is no matrix.
+// A map with the only "__ConstantChoices__" key is replaced with every item of its list,
+// scenarios are all combinations of choices. The number of scenarios is limited by MaxMatrixScenarios.
+func MatrixScenarios(path string) ([]Scenario, error) {
+	values, err := chartutil.ReadValuesFile(filepath.Join(path, ValuesMatrixFilename))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ValuesMatrixFilename, err)
+	}
+
+	variants := expandMatrix(values.AsMap())
+
+	scenarios := make([]Scenario, 0, len(variants))
+	for i, variant := range variants {
+		v, _ := variant.(map[string]any)
+		scenarios = append(scenarios, Scenario{Name: fmt.Sprintf("%s#%d", ValuesMatrixFilename, i+1), Values: v})
+	}
+
+	return scenarios, nil
+}
+
+// expandMatrix returns all variants of the value with choices.
+func expandMatrix(value any) []any {
+	switch v := value.(type) {
+	case map[string]any:
+		if choices, ok := v[constantChoicesKey].([]any); ok && len(v) == 1 {
+			var result []any
+			for _, choice := range choices {
+				result = append(result, expandMatrix(choice)...)
+			}
+
+			return limit(result)
+		}
+
+		result := []any{map[string]any{}}
+		for _, key := range slices.Sorted(maps.Keys(v)) {
+			result = product(result, expandMatrix(v[key]), func(acc, item any) any {
+				m := maps.Clone(acc.(map[string]any))
+				m[key] = item
+				return m
+			})
+		}
+
+		return result
+	case []any:
+		result := []any{[]any{}}
+		for _, item := range v {
+			result = product(result, expandMatrix(item), func(acc, item any) any {
+				return append(slices.Clone(acc.([]any)), item)
+			})
+		}
+
+		return result
+	default:
+		return []any{value}
+	}
+}
+
+// product combines every accumulated variant with every variant of the next item.
+func product(acc, items []any, combine func(acc, item any) any) []any {
+	result := make([]any, 0, len(acc)*len(items))
+	for _, a := range acc {
+		for _, item := range items {
+			result = append(result, combine(a, item))
+		}
+	}
+
+	return limit(result)
+}
+
+func limit(variants []any) []any {
+	if len(variants) > MaxMatrixScenarios {
+		return variants[:MaxMatrixScenarios]
+	}
+
+	return variants
+}
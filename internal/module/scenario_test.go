@@ -0,0 +1,51 @@
+package module
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatrixScenarios(t *testing.T) {
+	dir := t.TempDir()
+
+	scenarios, err := MatrixScenarios(dir)
+	require.NoError(t, err)
+	require.Nil(t, scenarios)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ValuesMatrixFilename), []byte(`testModule:
+  replicas:
+    __ConstantChoices__: [1, 3]
+  mode: ha
+  ingress:
+    __ConstantChoices__:
+      - null
+      - class: nginx
+        tls:
+          __ConstantChoices__: [false, true]
+`), 0o600))
+
+	scenarios, err = MatrixScenarios(dir)
+	require.NoError(t, err)
+	require.Len(t, scenarios, 6)
+	require.Equal(t, "values_matrix_test.yaml#1", scenarios[0].Name)
+	require.Equal(t, map[string]any{"testModule": map[string]any{"replicas": float64(1), "mode": "ha", "ingress": nil}}, scenarios[0].Values)
+	require.Equal(t, map[string]any{"testModule": map[string]any{
+		"replicas": float64(3),
+		"mode":     "ha",
+		"ingress":  map[string]any{"class": "nginx", "tls": true},
+	}}, scenarios[5].Values)
+
+	// 256 combinations are limited
+	var content string
+	for _, key := range []string{"a", "b", "c", "d", "e", "f", "g", "h"} {
+		content += key + ":\n  __ConstantChoices__: [1, 2]\n"
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ValuesMatrixFilename), []byte(content), 0o600))
+
+	scenarios, err = MatrixScenarios(dir)
+	require.NoError(t, err)
+	require.Len(t, scenarios, MaxMatrixScenarios)
+}
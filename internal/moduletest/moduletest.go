@@ -0,0 +1,47 @@
+// Package moduletest creates modules for tests of linters and generators.
+package moduletest
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/module"
+)
+
+// Name is the name of test modules.
+const Name = "test-module"
+
+// defaultFiles are files of a minimal module, they are overridden by files passed to New.
+var defaultFiles = map[string]string{
+	module.ChartConfigFilename: "name: " + Name + "\nversion: 0.1.0\n",
+	"openapi/values.yaml":      "type: object\n",
+}
+
+// New writes the files of a module to a temporary directory and loads the module.
+// Paths of files are relative to the module directory.
+func New(t testing.TB, files map[string]string) *module.Module {
+	t.Helper()
+
+	dir := filepath.Join(t.TempDir(), Name)
+	WriteFiles(t, dir, defaultFiles)
+	WriteFiles(t, dir, files)
+
+	m, err := module.NewModule(dir)
+	require.NoError(t, err)
+
+	return m
+}
+
+// WriteFiles writes the files relative to the directory, parent directories are created.
+func WriteFiles(t testing.TB, dir string, files map[string]string) {
+	t.Helper()
+
+	for path, content := range files {
+		path = filepath.Join(dir, path)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+}
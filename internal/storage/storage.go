@@ -11,6 +11,8 @@ import (
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/deckhouse/dmt/pkg/errors"
 )
 
 const (
@@ -38,8 +40,14 @@ func (g *ResourceIndex) AsString() string {
 }
 
 type StoreObject struct {
-	Path         string
-	Hash         string
+	Path string
+	Hash string
+	// Document is the index of the YAML document in the rendered template, starting with 0.
+	Document int
+	// Line is the line of the document in the template source, it is 0 if the template produces documents dynamically.
+	Line int
+	// EndLine is the last line of the document in the template source, it is 0 if Line is 0.
+	EndLine      int
 	Unstructured unstructured.Unstructured
 }
 
@@ -326,6 +334,18 @@ func (s *StoreObject) ShortPath() string {
 	return strings.Join(path, string(os.PathSeparator))
 }
 
+// Location returns the location of the object: the template which produced it and lines of its document in the template.
+// The position is the start of the document with the column 1, keys of the object are not mapped to the template.
+// Lines are 0 if the number of rendered documents differs from the number of documents in the template source.
+func (s *StoreObject) Location() errors.Location {
+	location := errors.Location{File: s.ShortPath(), Line: s.Line, EndLine: s.EndLine}
+	if s.Line > 0 {
+		location.Column = 1
+	}
+
+	return location
+}
+
 func (s *StoreObject) Identity() string {
 	kind := s.Unstructured.GetKind()
 	name := s.Unstructured.GetName()
@@ -339,17 +359,43 @@ func (s *StoreObject) Identity() string {
 
 type UnstructuredObjectStore struct {
 	Storage map[ResourceIndex]StoreObject
+	// rendered contains hashes of rendered templates put into the store
+	rendered map[uint64]struct{}
 }
 
 func NewUnstructuredObjectStore() *UnstructuredObjectStore {
-	return &UnstructuredObjectStore{Storage: make(map[ResourceIndex]StoreObject)}
+	return &UnstructuredObjectStore{
+		Storage:  make(map[ResourceIndex]StoreObject),
+		rendered: make(map[uint64]struct{}),
+	}
 }
 
-func (s *UnstructuredObjectStore) Put(path string, object map[string]any, raw []byte) error {
+// MarkRendered records the hash of rendered templates, it returns false if templates with the hash are already in the store.
+func (s *UnstructuredObjectStore) MarkRendered(hash uint64) bool {
+	if _, ok := s.rendered[hash]; ok {
+		return false
+	}
+	if s.rendered == nil {
+		s.rendered = make(map[uint64]struct{})
+	}
+	s.rendered[hash] = struct{}{}
+
+	return true
+}
+
+// Put adds the object rendered from the document of the template, lines are lines of the document in the template source.
+func (s *UnstructuredObjectStore) Put(path string, object map[string]any, raw []byte, document, line, endLine int) error {
 	var u unstructured.Unstructured
 	u.SetUnstructuredContent(object)
 
-	storeObject := StoreObject{Path: path, Unstructured: u, Hash: NewSHA256(raw)}
+	storeObject := StoreObject{
+		Path:         path,
+		Document:     document,
+		Line:         line,
+		EndLine:      endLine,
+		Unstructured: u,
+		Hash:         NewSHA256(raw),
+	}
 
 	index := GetResourceIndex(storeObject)
 	if _, ok := s.Storage[index]; ok {
@@ -376,6 +422,7 @@ func (s *UnstructuredObjectStore) Exists(key ResourceIndex) bool {
 
 func (s *UnstructuredObjectStore) Close() {
 	s.Storage = make(map[ResourceIndex]StoreObject)
+	s.rendered = make(map[uint64]struct{})
 }
 
 func NewSHA256(data []byte) string {
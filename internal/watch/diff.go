@@ -0,0 +1,62 @@
+package watch
+
+import (
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// key identifies a finding between runs, the location is not a part of it,
+// so a finding moved inside a file is unchanged.
+type key struct {
+	module, linter, id, rule, object, text string
+}
+
+func newKey(e *errors.LintRuleError) key {
+	return key{module: e.Module, linter: e.Linter, id: e.ID, rule: e.Rule, object: e.ObjectID, text: e.Text}
+}
+
+// Diff compares findings of two runs: added findings are only in the second run, fixed ones are only in the first run.
+// Findings which occur several times are compared by their number, findings at the same location are matched first.
+func Diff(before, after []*errors.LintRuleError) (added, fixed, unchanged []*errors.LintRuleError) {
+	matched := make(map[*errors.LintRuleError]bool)
+
+	// exact matches first, then findings which moved
+	for _, sameLocation := range []bool{true, false} {
+		candidates := make(map[key][]*errors.LintRuleError)
+		for _, e := range before {
+			if !matched[e] {
+				candidates[newKey(e)] = append(candidates[newKey(e)], e)
+			}
+		}
+
+		for _, e := range after {
+			if matched[e] {
+				continue
+			}
+
+			k := newKey(e)
+			for i, c := range candidates[k] {
+				if sameLocation && c.Location != e.Location {
+					continue
+				}
+				matched[c], matched[e] = true, true
+				candidates[k] = append(candidates[k][:i], candidates[k][i+1:]...)
+				break
+			}
+		}
+	}
+
+	for _, e := range after {
+		if matched[e] {
+			unchanged = append(unchanged, e)
+		} else {
+			added = append(added, e)
+		}
+	}
+	for _, e := range before {
+		if !matched[e] {
+			fixed = append(fixed, e)
+		}
+	}
+
+	return added, fixed, unchanged
+}
@@ -0,0 +1,127 @@
+// Package watch reports changes of files in module directories and compares findings of consecutive lint runs.
+package watch
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/deckhouse/dmt/internal/logger"
+)
+
+// debounce is the time without events after which changes are reported, editors write files in several steps.
+const debounce = 300 * time.Millisecond
+
+// skippedDirs are directories which are not watched.
+var skippedDirs = []string{".git", "charts", "node_modules"}
+
+// Watcher watches directories recursively.
+type Watcher struct {
+	w *fsnotify.Watcher
+}
+
+// New watches the directories with their subdirectories and the extra directories without subdirectories,
+// e.g. directories of config files.
+func New(dirs, extra []string) (*Watcher, error) {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, err
+	}
+
+	watcher := &Watcher{w: w}
+	for _, dir := range dirs {
+		if _, err = watcher.addRecursive(dir); err != nil {
+			_ = w.Close()
+			return nil, err
+		}
+	}
+	for _, dir := range extra {
+		if slices.Contains(w.WatchList(), dir) {
+			continue
+		}
+		if err = w.Add(dir); err != nil {
+			logger.DebugF("Cannot watch %s: %s", dir, err)
+		}
+	}
+
+	return watcher, nil
+}
+
+// addRecursive watches the directory with its subdirectories and returns files in them.
+func (w *Watcher) addRecursive(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() {
+			files = append(files, path)
+			return nil
+		}
+		if path != dir && slices.Contains(skippedDirs, d.Name()) {
+			return filepath.SkipDir
+		}
+
+		return w.w.Add(path)
+	})
+
+	return files, err
+}
+
+// Next waits for changes and returns absolute paths of changed files, it returns an error if the context is done.
+// Changes are collected until there are no events for a while, new directories are watched as well.
+func (w *Watcher) Next(ctx context.Context) ([]string, error) {
+	var (
+		changed []string
+		timer   <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case err, ok := <-w.w.Errors:
+			if !ok {
+				return nil, fs.ErrClosed
+			}
+			logger.WarnF("Watch error: %s", err)
+		case event, ok := <-w.w.Events:
+			if !ok {
+				return nil, fs.ErrClosed
+			}
+			if event.Op == fsnotify.Chmod {
+				continue
+			}
+
+			paths := []string{event.Name}
+			// files may be written to a new directory before it is watched
+			if event.Has(fsnotify.Create) {
+				if info, err := os.Stat(event.Name); err == nil && info.IsDir() {
+					files, err := w.addRecursive(event.Name)
+					if err != nil {
+						logger.WarnF("Cannot watch %s: %s", event.Name, err)
+					}
+					paths = append(paths, files...)
+				}
+			}
+
+			for _, path := range paths {
+				if path, err := filepath.Abs(path); err == nil && !slices.Contains(changed, path) {
+					changed = append(changed, path)
+				}
+			}
+			timer = time.After(debounce)
+		case <-timer:
+			return changed, nil
+		}
+	}
+}
+
+func (w *Watcher) Close() error {
+	return w.w.Close()
+}
@@ -0,0 +1,66 @@
+package watch
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestDiff(t *testing.T) {
+	finding := func(text string, line int) *errors.LintRuleError {
+		return errors.NewLintRuleError("id", "object", "module", nil, "%s", text).WithFilePath("templates/a.yaml").WithPosition(line, 1)
+	}
+
+	before := []*errors.LintRuleError{finding("fixed", 1), finding("moved", 2), finding("twice", 3), finding("twice", 4)}
+	after := []*errors.LintRuleError{finding("moved", 5), finding("twice", 3), finding("new", 6)}
+
+	added, fixed, unchanged := Diff(before, after)
+	require.Equal(t, []*errors.LintRuleError{after[2]}, added)
+	require.Equal(t, []*errors.LintRuleError{before[0], before[3]}, fixed)
+	require.Equal(t, []*errors.LintRuleError{after[0], after[1]}, unchanged)
+}
+
+func TestWatcher(t *testing.T) {
+	dir, err := filepath.EvalSymlinks(t.TempDir())
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "module", "templates"), 0o755))
+
+	w, err := New([]string{dir}, nil)
+	require.NoError(t, err)
+	defer w.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	file := filepath.Join(dir, "module", "templates", "a.yaml")
+	require.NoError(t, os.WriteFile(file, []byte("a"), 0o600))
+
+	changed, err := w.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{file}, changed)
+
+	// files of new directories are reported and the directories are watched
+	newDir := filepath.Join(dir, "module", "crds")
+	require.NoError(t, os.MkdirAll(newDir, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(newDir, "crd.yaml"), []byte("a"), 0o600))
+
+	changed, err = w.Next(ctx)
+	require.NoError(t, err)
+	require.Contains(t, changed, newDir)
+	require.Contains(t, changed, filepath.Join(newDir, "crd.yaml"))
+
+	require.NoError(t, os.WriteFile(filepath.Join(newDir, "crd.yaml"), []byte("b"), 0o600))
+	changed, err = w.Next(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(newDir, "crd.yaml")}, changed)
+
+	cancel()
+	_, err = w.Next(ctx)
+	require.ErrorIs(t, err, context.Canceled)
+}
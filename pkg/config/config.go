@@ -1,25 +1,124 @@
 package config
 
 import (
-	"github.com/deckhouse/dmt/pkg/errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/mitchellh/mapstructure"
 )
 
 // Config encapsulates the config data specified in the YAML config file.
 type Config struct {
-	cfgDir string // The directory containing the config file.
+	cfgDir string   // The directory containing the config file.
+	files  []string // Config files merged into the config, from the lowest priority to the highest one.
+	opts   LoaderOptions
+
+	// modules caches configs of modules by their config files, it is shared by all configs of the run.
+	modules map[string]*Config
+	// usage records exclusions which matched, it is shared by all configs of the run.
+	usage *ExclusionUsage
+	// exclusions contains exclusion entries with details from config files, including expired ones.
+	exclusions []ExclusionEntry
 
+	Linters         LintersConfig   `mapstructure:"linters"`
 	LintersSettings LintersSettings `mapstructure:"linters-settings"`
-	WarningsOnly    []string        `mapstructure:"warnings-only"`
+	// WarningsOnly contains IDs of errors which have the warning severity.
+	WarningsOnly      []string           `mapstructure:"warnings-only"`
+	SeverityOverrides []SeverityOverride `mapstructure:"severity-overrides"`
+	RulePacks         []RulePack         `mapstructure:"rule-packs"`
+	CustomRules       []CustomRule       `mapstructure:"custom-rules"`
 }
 
-func NewDefault(dirs []string) (*Config, error) {
-	cfg := &Config{}
+func NewDefault(dirs []string, opts LoaderOptions) (*Config, error) {
+	cfg := &Config{modules: make(map[string]*Config), usage: newExclusionUsage()}
 
-	if err := NewLoader(cfg, dirs).Load(); err != nil {
+	if err := NewLoader(cfg, dirs, opts).Load(); err != nil {
 		return nil, err
 	}
 
-	errors.WarningsOnly = cfg.WarningsOnly
+	return cfg, nil
+}
+
+// Files returns config files merged into the config, from the lowest priority to the highest one.
+func (c *Config) Files() []string {
+	return c.files
+}
+
+// ForModule returns the config of the module in the directory. The ".dmtlint" file of the module and files of
+// its parent directories are merged, settings of the module file override settings of the repository-level files.
+// If the config file is set explicitly, only the ".dmtlint" file of the module is merged into it.
+func (c *Config) ForModule(dir string) (*Config, error) {
+	cfg := &Config{modules: c.modules, usage: c.usage}
+	l := NewLoader(cfg, []string{dir}, c.opts)
+
+	files := l.configFiles(dir)
+	if c.opts.Config != "" {
+		if file := findConfigFile(dir); file != "" && !slices.Contains(files, file) {
+			files = append(files, file)
+		}
+	}
+
+	if slices.Equal(files, c.files) {
+		return c, nil
+	}
+
+	if c.modules == nil {
+		c.modules = make(map[string]*Config)
+		cfg.modules = c.modules
+	}
+
+	key := strings.Join(files, string(os.PathListSeparator))
+	if cached, ok := c.modules[key]; ok {
+		return cached, nil
+	}
+
+	if err := l.load(files); err != nil {
+		return nil, fmt.Errorf("module %s: %w", dir, err)
+	}
+	c.modules[key] = cfg
 
 	return cfg, nil
 }
+
+// AsMap returns the effective config as a map with the same keys as in config files.
+func (c *Config) AsMap() (map[string]any, error) {
+	result := make(map[string]any)
+	if err := mapstructure.Decode(c, &result); err != nil {
+		return nil, err
+	}
+
+	// mapstructure doesn't convert structs in slices
+	overrides := make([]map[string]any, 0, len(c.SeverityOverrides))
+	for i := range c.SeverityOverrides {
+		override := make(map[string]any)
+		if err := mapstructure.Decode(&c.SeverityOverrides[i], &override); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, override)
+	}
+	result["severity-overrides"] = overrides
+
+	packs := make([]map[string]any, 0, len(c.RulePacks))
+	for i := range c.RulePacks {
+		pack := make(map[string]any)
+		if err := mapstructure.Decode(&c.RulePacks[i], &pack); err != nil {
+			return nil, err
+		}
+		packs = append(packs, pack)
+	}
+	result[rulePacksKey] = packs
+
+	rules := make([]map[string]any, 0, len(c.CustomRules))
+	for i := range c.CustomRules {
+		rule := make(map[string]any)
+		if err := mapstructure.Decode(&c.CustomRules[i], &rule); err != nil {
+			return nil, err
+		}
+		rules = append(rules, rule)
+	}
+	result["custom-rules"] = rules
+
+	return result, nil
+}
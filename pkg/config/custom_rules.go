@@ -0,0 +1,83 @@
+package config
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+
+	"github.com/gobwas/glob"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// customRuleIDPattern is the format of IDs of custom rules, they are used in "custom-rules/<ID>" selectors.
+var customRuleIDPattern = regexp.MustCompile(`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`)
+
+// CustomRule is a check of rendered objects written as a CEL expression, see the custom-rules linter.
+type CustomRule struct {
+	ID string `mapstructure:"id" required:"true"`
+	// Message is the text of findings of the rule.
+	Message  string `mapstructure:"message" required:"true"`
+	Severity string `mapstructure:"severity" enum:"error,warning,info"`
+	// Match selects objects checked by the rule, all objects are checked if it is empty.
+	Match CustomRuleMatch `mapstructure:"match"`
+	// Expression must evaluate to true for valid objects.
+	Expression string `mapstructure:"expression" required:"true"`
+
+	severity   errors.Severity
+	namespaces []glob.Glob
+}
+
+// CustomRuleMatch selects objects by their kinds and namespaces, empty conditions match everything.
+type CustomRuleMatch struct {
+	Kinds []string `mapstructure:"kinds"`
+	// Namespaces contains globs of namespaces, e.g. "d8-*". Cluster-scoped objects match only the "" namespace.
+	Namespaces []string `mapstructure:"namespaces"`
+}
+
+// Matches reports whether the rule checks objects of the kind in the namespace.
+func (r *CustomRule) Matches(kind, namespace string) bool {
+	if len(r.Match.Kinds) > 0 && !slices.Contains(r.Match.Kinds, kind) {
+		return false
+	}
+
+	if len(r.Match.Namespaces) > 0 && !matchAny(r.namespaces, namespace) {
+		return false
+	}
+
+	return true
+}
+
+// DefaultSeverity returns the severity of findings of the rule, it is empty if it is not set.
+func (r *CustomRule) DefaultSeverity() errors.Severity {
+	return r.severity
+}
+
+// compileCustomRules validates custom rules and prepares them for matching, expressions are compiled by the linter.
+func (c *Config) compileCustomRules() error {
+	ids := make(map[string]struct{})
+	for i := range c.CustomRules {
+		rule := &c.CustomRules[i]
+		if !customRuleIDPattern.MatchString(rule.ID) {
+			return fmt.Errorf("custom-rules[%d]: invalid ID %q, it must contain lower-case letters, digits and dashes", i, rule.ID)
+		}
+		if _, ok := ids[rule.ID]; ok {
+			return fmt.Errorf("custom-rules[%d]: duplicate ID %q", i, rule.ID)
+		}
+		ids[rule.ID] = struct{}{}
+
+		var err error
+		if rule.Severity != "" {
+			if rule.severity, err = errors.ParseSeverity(rule.Severity); err != nil {
+				return fmt.Errorf("custom-rules[%d]: %w", i, err)
+			}
+		}
+
+		rule.namespaces, err = compileGlobs(rule.Match.Namespaces)
+		if err != nil {
+			return fmt.Errorf("custom-rules[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
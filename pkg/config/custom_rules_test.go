@@ -0,0 +1,42 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestCustomRule_Matches(t *testing.T) {
+	cfg := &Config{CustomRules: []CustomRule{{
+		ID:       "service-owner",
+		Severity: "Warning",
+		Match:    CustomRuleMatch{Kinds: []string{"Service"}, Namespaces: []string{"d8-*"}},
+	}}}
+	require.NoError(t, cfg.compileCustomRules())
+
+	rule := &cfg.CustomRules[0]
+	require.Equal(t, errors.SeverityWarning, rule.DefaultSeverity())
+	require.True(t, rule.Matches("Service", "d8-system"))
+	require.False(t, rule.Matches("Service", "default"))
+	require.False(t, rule.Matches("Deployment", "d8-system"))
+	require.False(t, rule.Matches("Service", ""))
+
+	all := &CustomRule{ID: "all"}
+	require.True(t, all.Matches("ClusterRole", ""))
+}
+
+func TestConfig_compileCustomRules(t *testing.T) {
+	cfg := &Config{CustomRules: []CustomRule{{ID: "Owner"}}}
+	require.ErrorContains(t, cfg.compileCustomRules(), "invalid ID")
+
+	cfg = &Config{CustomRules: []CustomRule{{ID: "owner"}, {ID: "owner"}}}
+	require.ErrorContains(t, cfg.compileCustomRules(), `custom-rules[1]: duplicate ID "owner"`)
+
+	cfg = &Config{CustomRules: []CustomRule{{ID: "owner", Severity: "fatal"}}}
+	require.ErrorContains(t, cfg.compileCustomRules(), "unknown severity")
+
+	cfg = &Config{CustomRules: []CustomRule{{ID: "owner", Match: CustomRuleMatch{Namespaces: []string{"d8-["}}}}}
+	require.ErrorContains(t, cfg.compileCustomRules(), "invalid glob")
+}
@@ -0,0 +1,505 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// lintersSettingsKey is the key of linters settings in config files.
+const lintersSettingsKey = "linters-settings"
+
+// Exclusion is an entry of an exclusion setting of a linter, e.g. a file in "license.copyright-excludes".
+type Exclusion struct {
+	// Setting is the path of the setting in linters settings, e.g. "license.copyright-excludes".
+	Setting string
+	// Key is the key of the entry in settings which are maps, e.g. a namespace in "probes.probes-excludes".
+	Key   string
+	Value string
+}
+
+func (e Exclusion) String() string {
+	if e.Key != "" {
+		return fmt.Sprintf("%s.%s.%s: %s", lintersSettingsKey, e.Setting, e.Key, e.Value)
+	}
+
+	return fmt.Sprintf("%s.%s: %s", lintersSettingsKey, e.Setting, e.Value)
+}
+
+// ExclusionUsage records exclusion entries which suppressed findings during a run, it is shared by configs of all modules.
+type ExclusionUsage struct {
+	mu   sync.Mutex
+	used map[Exclusion]bool
+}
+
+func newExclusionUsage() *ExclusionUsage {
+	return &ExclusionUsage{used: make(map[Exclusion]bool)}
+}
+
+func (u *ExclusionUsage) use(e Exclusion) {
+	if u == nil {
+		return
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	u.used[e] = true
+}
+
+// IsUsed reports whether the exclusion suppressed a finding during the run.
+func (u *ExclusionUsage) IsUsed(e Exclusion) bool {
+	if u == nil {
+		return false
+	}
+
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	return u.used[e]
+}
+
+// match returns entries of the setting which match.
+func (u *ExclusionUsage) match(setting, key string, entries []string, match func(entry string) bool) Match {
+	result := Match{usage: u}
+	for _, entry := range entries {
+		if match(entry) {
+			result.entries = append(result.entries, Exclusion{Setting: setting, Key: key, Value: entry})
+		}
+	}
+
+	return result
+}
+
+// contains returns the entry of the setting which is equal to the value.
+func (u *ExclusionUsage) contains(setting, key string, entries []string, value string) Match {
+	return u.match(setting, key, entries, func(entry string) bool { return entry == value })
+}
+
+// Match is the result of an exclusion lookup. Matched entries are recorded as used only by Suppress,
+// so an entry guarding a check which found nothing is reported as unused.
+type Match struct {
+	usage   *ExclusionUsage
+	entries []Exclusion
+}
+
+// Matched reports whether any exclusion entry matched.
+func (m Match) Matched() bool {
+	return len(m.entries) > 0
+}
+
+// Suppress reports whether a finding is dropped by the matched entries and records them as used.
+// Linters call it only when the guarded check found something.
+func (m Match) Suppress() bool {
+	for _, e := range m.entries {
+		m.usage.use(e)
+	}
+
+	return m.Matched()
+}
+
+// or returns entries of both matches.
+func (m Match) or(other Match) Match {
+	if m.usage == nil {
+		m.usage = other.usage
+	}
+	m.entries = append(m.entries, other.entries...)
+
+	return m
+}
+
+func (s *LintersSettings) setUsage(usage *ExclusionUsage) {
+	s.OpenAPI.usage = usage
+	s.NoCyrillic.usage = usage
+	s.License.usage = usage
+	s.Probes.usage = usage
+	s.Container.usage = usage
+	s.K8SResources.usage = usage
+	s.Helm.usage = usage
+	s.Rbac.usage = usage
+	s.Monitoring.usage = usage
+}
+
+// IsEnumExcluded reports whether the enum of any of the properties in the OpenAPI file ("<module>:/<path>") is not checked.
+// Entries of the "*" key are excluded in all files.
+func (s *OpenAPISettings) IsEnumExcluded(file string, properties ...string) Match {
+	var result Match
+	for _, key := range []string{"*", file} {
+		result = result.or(s.usage.match("openapi.enum-file-excludes", key, s.EnumFileExcludes[key], func(entry string) bool {
+			return slices.Contains(properties, entry)
+		}))
+	}
+
+	return result
+}
+
+// IsHAKeyExcluded reports whether the key in the OpenAPI file ("<module>:/<path>") may have a default value.
+func (s *OpenAPISettings) IsHAKeyExcluded(file, absoluteKey string) Match {
+	excluded, ok := s.HAAbsoluteKeysExcludes[file]
+	if !ok {
+		return Match{usage: s.usage}
+	}
+
+	return s.usage.contains("openapi.ha-absolute-keys-excludes", file, []string{excluded}, absoluteKey)
+}
+
+// IsFileExcluded reports whether the file ("<module>:/<path>") is not checked for cyrillic letters.
+func (s *NoCyrillicSettings) IsFileExcluded(file string) Match {
+	return s.usage.contains("nocyrillic.no-cyrillic-file-excludes", "", s.NoCyrillicFileExcludes, file)
+}
+
+// IsCopyrightExcluded reports whether the file ("<module>:/<path>") is not checked for a copyright.
+func (s *LicenseSettings) IsCopyrightExcluded(file string) Match {
+	return s.usage.contains("license.copyright-excludes", "", s.CopyrightExcludes, file)
+}
+
+// IsOssCheckSkipped reports whether the oss.yaml of the module is not checked.
+func (s *LicenseSettings) IsOssCheckSkipped(module string) Match {
+	return s.usage.contains("license.skip-oss-checks", "", s.SkipOssChecks, module)
+}
+
+// IsContainerExcluded reports whether probes of the container in the namespace are not checked.
+func (s *ProbesSettings) IsContainerExcluded(namespace, container string) Match {
+	return s.usage.contains("probes.probes-excludes", namespace, s.ProbesExcludes[namespace], container)
+}
+
+// IsContainerSkipped reports whether the container of the object is not checked.
+// Entries have the "<name>:<container>" format, "*" in the container name matches any substring.
+func (s *ContainerSettings) IsContainerSkipped(name, container string) Match {
+	return s.usage.match("container.skip-containers", "", s.SkipContainers, func(entry string) bool {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 { //nolint:mnd // the name and the container
+			return false
+		}
+		entryName := strings.TrimSpace(parts[0])
+		entryContainer := strings.TrimSpace(parts[1])
+
+		matched := container == entryContainer
+		subString := strings.Trim(entryContainer, "*")
+		if len(subString) != len(entryContainer) {
+			matched = strings.Contains(container, subString)
+		}
+
+		return name == entryName && matched
+	})
+}
+
+// IsKubeRbacProxyCheckSkipped reports whether the namespace is not checked for the kube-rbac-proxy CA.
+func (s *K8SResourcesSettings) IsKubeRbacProxyCheckSkipped(namespace string) Match {
+	return s.usage.contains("k8s_resources.skip-kube-rbac-proxy-checks", "", s.SkipKubeRbacProxyChecks, namespace)
+}
+
+// IsContainerCheckSkipped reports whether the object is not checked.
+func (s *K8SResourcesSettings) IsContainerCheckSkipped(name string) Match {
+	return s.usage.contains("k8s_resources.skip-container-checks", "", s.SkipContainerChecks, name)
+}
+
+// IsVPACheckSkipped reports whether the module ("<namespace>:<module>") is not checked for VPAs.
+func (s *K8SResourcesSettings) IsVPACheckSkipped(module string) Match {
+	return s.usage.contains("k8s_resources.skip-vpa-checks", "", s.SkipVPAChecks, module)
+}
+
+// IsPDBCheckSkipped reports whether the module ("<namespace>:<module>") is not checked for PDBs.
+func (s *K8SResourcesSettings) IsPDBCheckSkipped(module string) Match {
+	return s.usage.contains("k8s_resources.skip-pdb-checks", "", s.SkipPDBChecks, module)
+}
+
+// IsModuleCheckSkipped reports whether monitoring of the module is not checked.
+func (s *MonitoringSettings) IsModuleCheckSkipped(module string) Match {
+	return s.usage.contains("monitoring.skip-module-checks", "", s.SkipModuleChecks, module)
+}
+
+// IsWildcardCheckSkipped reports whether the role in the file is allowed to have wildcards.
+func (s *RbacSettings) IsWildcardCheckSkipped(path, name string) Match {
+	result := Match{usage: s.usage}
+	for key, names := range s.SkipCheckWildcards {
+		if strings.EqualFold(path, key) {
+			result = result.or(s.usage.contains("rbac.skip-check-wildcards", key, names, name))
+		}
+	}
+
+	return result
+}
+
+// IsModuleBindingCheckSkipped reports whether subjects of bindings of the module are not checked.
+func (s *RbacSettings) IsModuleBindingCheckSkipped(module string) Match {
+	return s.usage.contains("rbac.skip-module-check-binding", "", s.SkipModuleCheckBinding, module)
+}
+
+// IsObjectBindingCheckSkipped reports whether placement of RBAC objects of the module is not checked.
+func (s *RbacSettings) IsObjectBindingCheckSkipped(module string) Match {
+	return s.usage.contains("rbac.skip-object-check-binding", "", s.SkipObjectCheckBinding, module)
+}
+
+// IsModuleImageNameSkipped reports whether the image file is not checked for the module image name.
+func (s *HelmSettings) IsModuleImageNameSkipped(path string) Match {
+	return s.usage.match("helm.skip-module-image-name", "", s.SkipModuleImageName, func(entry string) bool {
+		return strings.HasSuffix(path, entry)
+	})
+}
+
+// IsDistrolessImageCheckSkipped reports whether the image is not checked for a distroless base image.
+func (s *HelmSettings) IsDistrolessImageCheckSkipped(image string) Match {
+	return s.usage.match("helm.skip-distroless-image-check", "", s.SkipDistrolessImageCheck, func(entry string) bool {
+		return strings.HasSuffix(image, entry)
+	})
+}
+
+// IsHelmIgnoreCheckSkipped reports whether the .helmignore file of the module is not checked.
+func (s *HelmSettings) IsHelmIgnoreCheckSkipped(module string) Match {
+	return s.usage.contains("helm.skip-helm-ignore-check", "", s.SkipHelmIgnoreCheck, module)
+}
+
+// IsNamespaceCheckSkipped reports whether the .namespace file of the module is not checked.
+func (s *HelmSettings) IsNamespaceCheckSkipped(module string) Match {
+	return s.usage.contains("helm.skip-namespace-check", "", s.SkipNamespaceCheck, module)
+}
+
+// UnusedExclusions returns exclusion entries of config files which did not suppress any finding during the run by config files.
+// Exclusions of disabled linters and rules are not returned.
+func (c *Config) UnusedExclusions() (map[string][]Exclusion, error) {
+	files := slices.Clone(c.files)
+	for _, cfg := range c.modules {
+		for _, file := range cfg.files {
+			if !slices.Contains(files, file) {
+				files = append(files, file)
+			}
+		}
+	}
+
+	rules := exclusionRules()
+
+	result := make(map[string][]Exclusion)
+	for _, file := range files {
+		settings, _, err := readConfigFile(file)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, e := range fileExclusions(settings) {
+			rule, ok := rules[e.Setting]
+			if !ok || !c.isExclusionEnabled(rule) {
+				continue
+			}
+
+			if !c.usage.IsUsed(e) {
+				result[file] = append(result[file], e)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// isExclusionEnabled reports whether the linter or the rule affected by the exclusion is enabled.
+func (c *Config) isExclusionEnabled(rule exclusionRule) bool {
+	if rule.rule == "" {
+		return c.Linters.IsLinterEnabled(rule.linter)
+	}
+
+	return c.Linters.IsRuleEnabled(rule.linter, rule.rule)
+}
+
+type exclusionRule struct {
+	linter string
+	rule   string
+}
+
+// exclusionRules returns linters and rules of exclusion settings, they are set by "linter" and "exclusion" tags.
+// The rule is empty if the exclusion affects all rules of the linter.
+func exclusionRules() map[string]exclusionRule {
+	result := make(map[string]exclusionRule)
+
+	lintersType := reflect.TypeOf(LintersSettings{})
+	for i := range lintersType.NumField() {
+		linterField := lintersType.Field(i)
+		settingsType := linterField.Type
+		for j := range settingsType.NumField() {
+			field := settingsType.Field(j)
+			rule, ok := field.Tag.Lookup("exclusion")
+			if !ok {
+				continue
+			}
+
+			setting := linterField.Tag.Get("mapstructure") + "." + field.Tag.Get("mapstructure")
+			result[setting] = exclusionRule{linter: linterField.Tag.Get("linter"), rule: rule}
+		}
+	}
+
+	return result
+}
+
+// fileExclusions returns all exclusion entries from settings of a config file.
+func fileExclusions(settings map[string]any) []Exclusion {
+	var result []Exclusion
+
+	lintersSettings, _ := settings[lintersSettingsKey].(map[string]any)
+	for linter, value := range lintersSettings {
+		linterSettings, _ := value.(map[string]any)
+		for name, entries := range linterSettings {
+			setting := linter + "." + name
+			forEachEntry(entries, func(key, value string) {
+				result = append(result, Exclusion{Setting: setting, Key: key, Value: value})
+			})
+		}
+	}
+
+	slices.SortFunc(result, func(a, b Exclusion) int {
+		return strings.Compare(a.String(), b.String())
+	})
+
+	return result
+}
+
+// forEachEntry calls the function for entries of a list, a map of lists and a list of maps of lists.
+func forEachEntry(entries any, f func(key, value string)) {
+	switch v := entries.(type) {
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				f("", s)
+				continue
+			}
+			forEachEntry(item, f)
+		}
+	case map[string]any:
+		for key, item := range v {
+			forEachEntry(item, func(_, value string) { f(key, value) })
+		}
+	case string:
+		f("", v)
+	}
+}
+
+// RemoveExclusions removes exclusion entries from the YAML config file, comments of other entries are kept.
+func RemoveExclusions(file string, exclusions []Exclusion) error {
+	if ext := filepath.Ext(file); ext != "" && ext != ".yaml" && ext != ".yml" {
+		return fmt.Errorf("only YAML config files can be rewritten, %s is not", file)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return fmt.Errorf("read config %s: %w", file, err)
+	}
+
+	var doc yaml.Node
+	if err = yaml.Unmarshal(content, &doc); err != nil {
+		return fmt.Errorf("parse config %s: %w", file, err)
+	}
+
+	for _, e := range exclusions {
+		removeExclusion(&doc, e)
+	}
+
+	buf := &bytes.Buffer{}
+	encoder := yaml.NewEncoder(buf)
+	encoder.SetIndent(2) //nolint:mnd // the indent of config examples
+	if err = encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("encode config %s: %w", file, err)
+	}
+
+	info, err := os.Stat(file)
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(file, buf.Bytes(), info.Mode())
+}
+
+func removeExclusion(doc *yaml.Node, e Exclusion) {
+	if len(doc.Content) == 0 {
+		return
+	}
+
+	linter, name, _ := strings.Cut(e.Setting, ".")
+	settings := mappingValue(mappingValue(doc.Content[0], lintersSettingsKey), linter)
+	node := mappingValue(settings, name)
+	if node == nil {
+		return
+	}
+
+	removeEntry(node, e.Key, e.Value)
+
+	// the setting without entries is removed
+	if len(node.Content) == 0 {
+		for i := 1; i < len(settings.Content); i += 2 {
+			if settings.Content[i] == node {
+				settings.Content = slices.Delete(settings.Content, i-1, i+1)
+				break
+			}
+		}
+	}
+}
+
+// removeEntry removes the value from a list, a map of lists or a list of maps of lists.
+func removeEntry(node *yaml.Node, key, value string) {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		node.Content = slices.DeleteFunc(node.Content, func(item *yaml.Node) bool {
+			if entry, ok := entryValue(item); ok {
+				return key == "" && entry == value
+			}
+
+			removeEntry(item, key, value)
+
+			return item.Kind == yaml.MappingNode && len(item.Content) == 0
+		})
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if !strings.EqualFold(node.Content[i].Value, key) {
+				continue
+			}
+
+			item := node.Content[i+1]
+			if entry, ok := entryValue(item); ok {
+				if entry == value {
+					node.Content = slices.Delete(node.Content, i, i+2)
+				}
+				return
+			}
+
+			removeEntry(item, "", value)
+			if item.Kind == yaml.SequenceNode && len(item.Content) == 0 {
+				node.Content = slices.Delete(node.Content, i, i+2)
+			}
+
+			return
+		}
+	default:
+	}
+}
+
+// entryValue returns the value of the entry which is a scalar or an object with details.
+func entryValue(node *yaml.Node) (string, bool) {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value, true
+	}
+
+	if value := mappingValue(node, "value"); value != nil && value.Kind == yaml.ScalarNode {
+		return value.Value, true
+	}
+
+	return "", false
+}
+
+// mappingValue returns the value of the key in the mapping node, keys are case-insensitive as in viper.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if strings.EqualFold(node.Content[i].Value, key) {
+			return node.Content[i+1]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_UnusedExclusions(t *testing.T) {
+	root := t.TempDir()
+	path := writeConfig(t, root, `
+linters:
+  disable: [helm]
+linters-settings:
+  # containers which are not checked
+  container:
+    skip-containers:
+      - "deployment:app" # keep
+      - "deployment:old"
+  probes:
+    probes-excludes:
+      d8-module:
+        - app
+      d8-old:
+        - app
+      d8-passed:
+        - app
+  openapi:
+    enum-file-excludes:
+      - module:/openapi/values.yaml:
+          - properties.mode
+          - properties.unused
+  helm:
+    skip-namespace-check:
+      - module
+`)
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{})
+	require.NoError(t, err)
+
+	require.True(t, cfg.LintersSettings.Container.IsContainerSkipped("deployment", "app").Suppress())
+	require.False(t, cfg.LintersSettings.Container.IsContainerSkipped("deployment", "sidecar").Suppress())
+	require.True(t, cfg.LintersSettings.Probes.IsContainerExcluded("d8-module", "app").Suppress())
+	require.True(t, cfg.LintersSettings.OpenAPI.IsEnumExcluded("module:/openapi/values.yaml", "properties.mode").Suppress())
+	// the entry matches, but the check it guards found nothing, so it is not used
+	require.True(t, cfg.LintersSettings.Probes.IsContainerExcluded("d8-passed", "app").Matched())
+
+	unused, err := cfg.UnusedExclusions()
+	require.NoError(t, err)
+	// exclusions of the disabled helm linter are not reported
+	require.Equal(t, map[string][]Exclusion{
+		path: {
+			{Setting: "container.skip-containers", Value: "deployment:old"},
+			{Setting: "openapi.enum-file-excludes", Key: "module:/openapi/values.yaml", Value: "properties.unused"},
+			{Setting: "probes.probes-excludes", Key: "d8-old", Value: "app"},
+			{Setting: "probes.probes-excludes", Key: "d8-passed", Value: "app"},
+		},
+	}, unused)
+
+	require.NoError(t, RemoveExclusions(path, unused[path]))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, `linters:
+  disable: [helm]
+linters-settings:
+  # containers which are not checked
+  container:
+    skip-containers:
+      - "deployment:app" # keep
+  probes:
+    probes-excludes:
+      d8-module:
+        - app
+  openapi:
+    enum-file-excludes:
+      - module:/openapi/values.yaml:
+          - properties.mode
+  helm:
+    skip-namespace-check:
+      - module
+`, string(content))
+
+	_, _, err = readConfigFile(path)
+	require.NoError(t, err)
+}
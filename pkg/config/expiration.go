@@ -0,0 +1,197 @@
+package config
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// dateLayout is the layout of dates in config files.
+const dateLayout = time.DateOnly
+
+// ExclusionEntry is an exclusion entry written as an object with details:
+//
+//	copyright-excludes:
+//	  - value: upmeter:/images/upmeter/stress.sh
+//	    reason: the script is taken from the upstream
+//	    until: 2026-12-31
+//	    issue: https://github.com/deckhouse/deckhouse/issues/1
+type ExclusionEntry struct {
+	Exclusion
+	// File is the config file with the entry.
+	File   string
+	Reason string
+	Issue  string
+	// Until is the last day the exclusion is applied, it is zero if the exclusion does not expire.
+	Until time.Time
+}
+
+// IsExpired reports whether the exclusion is not applied at the time.
+func (e ExclusionEntry) IsExpired(now time.Time) bool {
+	if e.Until.IsZero() {
+		return false
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	return today.After(e.Until)
+}
+
+// ExpiredExclusions returns exclusion entries of all config files of the run which are expired,
+// they are not applied. Exclusions of disabled linters and rules are not returned.
+func (c *Config) ExpiredExclusions() []ExclusionEntry {
+	now := time.Now()
+
+	return c.exclusionEntries(func(e ExclusionEntry) bool {
+		return e.IsExpired(now)
+	})
+}
+
+// ExpiringExclusions returns exclusion entries of all config files of the run which expire within the duration.
+// Exclusions of disabled linters and rules are not returned.
+func (c *Config) ExpiringExclusions(within time.Duration) []ExclusionEntry {
+	now := time.Now()
+
+	return c.exclusionEntries(func(e ExclusionEntry) bool {
+		return !e.Until.IsZero() && !e.IsExpired(now) && e.IsExpired(now.Add(within))
+	})
+}
+
+func (c *Config) exclusionEntries(filter func(e ExclusionEntry) bool) []ExclusionEntry {
+	rules := exclusionRules()
+
+	var result []ExclusionEntry
+	for _, cfg := range c.allConfigs() {
+		for _, e := range cfg.exclusions {
+			if !filter(e) || !cfg.isExclusionEnabled(rules[e.Setting]) || slices.Contains(result, e) {
+				continue
+			}
+			result = append(result, e)
+		}
+	}
+
+	slices.SortFunc(result, func(a, b ExclusionEntry) int {
+		return cmp.Or(a.Until.Compare(b.Until), cmp.Compare(a.File, b.File), cmp.Compare(a.String(), b.String()))
+	})
+
+	return result
+}
+
+// allConfigs returns the config and configs of modules.
+func (c *Config) allConfigs() []*Config {
+	result := []*Config{c}
+	for _, cfg := range c.modules {
+		if cfg != c {
+			result = append(result, cfg)
+		}
+	}
+
+	return result
+}
+
+// formatDates replaces dates parsed from YAML by strings, so they are validated and decoded as in JSON files.
+func formatDates(value any) any {
+	switch v := value.(type) {
+	case time.Time:
+		return v.Format(dateLayout)
+	case []any:
+		for i := range v {
+			v[i] = formatDates(v[i])
+		}
+	case map[string]any:
+		for key := range v {
+			v[key] = formatDates(v[key])
+		}
+	}
+
+	return value
+}
+
+// resolveExclusions replaces exclusion entries with details in settings by their values and drops expired entries.
+// Entries with details are returned.
+func resolveExclusions(file string, settings map[string]any, now time.Time) ([]ExclusionEntry, error) {
+	rules := exclusionRules()
+
+	var (
+		result []ExclusionEntry
+		err    error
+	)
+
+	lintersSettings, _ := settings[lintersSettingsKey].(map[string]any)
+	for linter, value := range lintersSettings {
+		linterSettings, _ := value.(map[string]any)
+		for name, entries := range linterSettings {
+			setting := linter + "." + name
+			if _, ok := rules[setting]; !ok {
+				continue
+			}
+
+			linterSettings[name] = resolveEntries(entries, "", func(key string, details map[string]any) (string, bool) {
+				e := ExclusionEntry{Exclusion: Exclusion{Setting: setting, Key: key}, File: file}
+				e.Value, _ = details["value"].(string)
+				e.Reason, _ = details["reason"].(string)
+				e.Issue, _ = details["issue"].(string)
+				if until, ok := details["until"].(string); ok {
+					var parseErr error
+					if e.Until, parseErr = time.Parse(dateLayout, until); parseErr != nil {
+						err = errors.Join(err, fmt.Errorf("%s: invalid date of the exclusion %s: %w", file, e.Exclusion, parseErr))
+					}
+				}
+				result = append(result, e)
+
+				return e.Value, !e.IsExpired(now)
+			})
+		}
+	}
+
+	return result, err
+}
+
+// resolveEntries calls the function for entries with details in a list, a map of lists and a list of maps of lists
+// and replaces them by the returned value. The entry is dropped if the function returns false.
+func resolveEntries(entries any, key string, resolve func(key string, details map[string]any) (string, bool)) any {
+	switch v := entries.(type) {
+	case []any:
+		result := make([]any, 0, len(v))
+		for _, item := range v {
+			if details, ok := entryDetails(item); ok {
+				if value, keep := resolve(key, details); keep {
+					result = append(result, value)
+				}
+				continue
+			}
+			result = append(result, resolveEntries(item, key, resolve))
+		}
+
+		return result
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for k, item := range v {
+			if details, ok := entryDetails(item); ok {
+				if value, keep := resolve(k, details); keep {
+					result[k] = value
+				}
+				continue
+			}
+			result[k] = resolveEntries(item, k, resolve)
+		}
+
+		return result
+	}
+
+	return entries
+}
+
+// entryDetails returns the entry if it is an object with the value.
+func entryDetails(item any) (map[string]any, bool) {
+	details, ok := item.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	_, ok = details["value"]
+
+	return details, ok
+}
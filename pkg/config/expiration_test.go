@@ -0,0 +1,126 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExclusionEntry_IsExpired(t *testing.T) {
+	until := time.Date(2026, time.December, 31, 0, 0, 0, 0, time.UTC)
+	e := ExclusionEntry{Until: until}
+
+	require.False(t, e.IsExpired(until.Add(23*time.Hour)))
+	require.True(t, e.IsExpired(until.AddDate(0, 0, 1)))
+	require.False(t, ExclusionEntry{}.IsExpired(until))
+}
+
+func TestConfig_ExpiredExclusions(t *testing.T) {
+	soon := time.Now().AddDate(0, 0, 10).Format(time.DateOnly)
+
+	root := t.TempDir()
+	path := writeConfig(t, root, fmt.Sprintf(`
+linters-settings:
+  license:
+    copyright-excludes:
+      - module:/permanent.sh
+      - value: module:/expired.sh
+        reason: taken from the upstream
+        until: 2020-01-01
+        issue: https://github.com/deckhouse/dmt/issues/1
+      - value: module:/soon.sh
+        until: %s
+      - value: module:/later.sh
+        until: "2999-12-31"
+  probes:
+    probes-excludes:
+      d8-module:
+        - value: app
+          until: 2020-01-01
+  openapi:
+    ha-absolute-keys-excludes:
+      module:/openapi/config-values.yaml:
+        value: properties.replicas
+        reason: replicas are set by the operator
+`, soon))
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{})
+	require.NoError(t, err)
+
+	require.True(t, cfg.LintersSettings.License.IsCopyrightExcluded("module:/permanent.sh").Suppress())
+	require.True(t, cfg.LintersSettings.License.IsCopyrightExcluded("module:/soon.sh").Suppress())
+	require.False(t, cfg.LintersSettings.License.IsCopyrightExcluded("module:/expired.sh").Suppress())
+	require.False(t, cfg.LintersSettings.Probes.IsContainerExcluded("d8-module", "app").Suppress())
+	require.True(t, cfg.LintersSettings.OpenAPI.IsHAKeyExcluded("module:/openapi/config-values.yaml", "properties.replicas").Suppress())
+
+	expired := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+	require.Equal(t, []ExclusionEntry{
+		{
+			Exclusion: Exclusion{Setting: "license.copyright-excludes", Value: "module:/expired.sh"},
+			File:      path,
+			Reason:    "taken from the upstream",
+			Issue:     "https://github.com/deckhouse/dmt/issues/1",
+			Until:     expired,
+		},
+		{
+			Exclusion: Exclusion{Setting: "probes.probes-excludes", Key: "d8-module", Value: "app"},
+			File:      path,
+			Until:     expired,
+		},
+	}, cfg.ExpiredExclusions())
+
+	expiring := cfg.ExpiringExclusions(30 * 24 * time.Hour)
+	require.Len(t, expiring, 1)
+	require.Equal(t, "module:/soon.sh", expiring[0].Value)
+
+	// entries with details are removed as plain entries
+	require.NoError(t, RemoveExclusions(path, []Exclusion{
+		{Setting: "license.copyright-excludes", Value: "module:/later.sh"},
+		{Setting: "openapi.ha-absolute-keys-excludes", Key: "module:/openapi/config-values.yaml", Value: "properties.replicas"},
+	}))
+
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	require.NotContains(t, string(content), "later.sh")
+	require.NotContains(t, string(content), "ha-absolute-keys-excludes")
+	require.Contains(t, string(content), "module:/soon.sh")
+}
+
+func TestConfig_DisabledExpiredExclusions(t *testing.T) {
+	root := t.TempDir()
+	writeConfig(t, root, `
+linters:
+  disable: [license/copyright]
+linters-settings:
+  license:
+    copyright-excludes:
+      - value: module:/expired.sh
+        until: 2020-01-01
+`)
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{})
+	require.NoError(t, err)
+	require.Empty(t, cfg.ExpiredExclusions())
+}
+
+func TestResolveExclusions_invalidDates(t *testing.T) {
+	settings := map[string]any{
+		lintersSettingsKey: map[string]any{
+			"license": map[string]any{
+				"copyright-excludes": []any{
+					map[string]any{"value": "module:/invalid.sh", "until": "31.12.2026"},
+					map[string]any{"value": "module:/valid.sh", "until": "2999-12-31"},
+				},
+			},
+		},
+	}
+
+	// the valid date of the next entry does not hide the invalid one
+	_, err := resolveExclusions(".dmtlint.yaml", settings, time.Now())
+	require.ErrorContains(t, err, "invalid date of the exclusion")
+	require.ErrorContains(t, err, "module:/invalid.sh")
+	require.NotContains(t, err.Error(), "module:/valid.sh")
+}
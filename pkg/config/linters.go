@@ -0,0 +1,65 @@
+package config
+
+import (
+	"slices"
+	"strings"
+)
+
+// ruleSeparator separates the linter name and the rule ID in selectors.
+const ruleSeparator = "/"
+
+// ToolLinter is the name of the linter of findings reported by dmt itself: linters which panicked or timed out,
+// expired exclusions and unused directives. Its rules are not selected by Enable, they are run unless they are disabled.
+const ToolLinter = "dmt"
+
+// LintersConfig selects linters and rules to run.
+// Selectors are linter names ("monitoring") or rules of linters ("k8s-resources/revision-history-limit").
+type LintersConfig struct {
+	// Enable contains selectors of linters and rules to run, all of them are run if it is empty.
+	Enable []string `mapstructure:"enable"`
+	// Disable contains selectors of linters and rules which are not run, it takes precedence over Enable.
+	Disable []string `mapstructure:"disable"`
+}
+
+// SplitSelector returns the linter name and the rule ID (empty for the whole linter) of the selector.
+func SplitSelector(selector string) (linter, rule string) {
+	linter, rule, _ = strings.Cut(selector, ruleSeparator)
+
+	return linter, rule
+}
+
+// IsLinterEnabled reports whether any rule of the linter is enabled.
+func (c *LintersConfig) IsLinterEnabled(linter string) bool {
+	if slices.Contains(c.Disable, linter) {
+		return false
+	}
+
+	if len(c.Enable) == 0 || linter == ToolLinter {
+		return true
+	}
+
+	return slices.ContainsFunc(c.Enable, func(selector string) bool {
+		l, _ := SplitSelector(selector)
+		return l == linter
+	})
+}
+
+// IsRuleEnabled reports whether the rule of the linter is enabled.
+// If only some rules of the linter are listed in Enable, other rules are disabled. Rules of ToolLinter are enabled
+// unless they are disabled.
+func (c *LintersConfig) IsRuleEnabled(linter, rule string) bool {
+	if !c.IsLinterEnabled(linter) {
+		return false
+	}
+
+	selector := linter + ruleSeparator + rule
+	if slices.Contains(c.Disable, selector) {
+		return false
+	}
+
+	if len(c.Enable) == 0 || linter == ToolLinter || slices.Contains(c.Enable, linter) {
+		return true
+	}
+
+	return slices.Contains(c.Enable, selector)
+}
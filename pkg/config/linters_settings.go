@@ -1,68 +1,94 @@
 package config
 
+// LintersSettings contains settings of linters, the "linter" tag is the name of the linter.
+// Exclusion settings have the "exclusion" tag with the rule they affect, it is empty if they affect all rules of the linter.
 type LintersSettings struct {
-	OpenAPI      OpenAPISettings      `mapstructure:"openapi"`
-	NoCyrillic   NoCyrillicSettings   `mapstructure:"nocyrillic"`
-	License      LicenseSettings      `mapstructure:"license"`
-	Probes       ProbesSettings       `mapstructure:"probes"`
-	Container    ContainerSettings    `mapstructure:"container"`
-	K8SResources K8SResourcesSettings `mapstructure:"k8s_resources"`
-	Helm         HelmSettings         `mapstructure:"helm"`
-	Rbac         RbacSettings         `mapstructure:"rbac"`
+	OpenAPI      OpenAPISettings      `mapstructure:"openapi" linter:"openapi"`
+	NoCyrillic   NoCyrillicSettings   `mapstructure:"nocyrillic" linter:"no-cyrillic"`
+	License      LicenseSettings      `mapstructure:"license" linter:"license"`
+	Probes       ProbesSettings       `mapstructure:"probes" linter:"probes"`
+	Container    ContainerSettings    `mapstructure:"container" linter:"container"`
+	K8SResources K8SResourcesSettings `mapstructure:"k8s_resources" linter:"k8s-resources"`
+	Helm         HelmSettings         `mapstructure:"helm" linter:"helm"`
+	Rbac         RbacSettings         `mapstructure:"rbac" linter:"rbac"`
 	Resources    ResourcesSettings    `mapstructure:"resources"`
-	Monitoring   MonitoringSettings   `mapstructure:"monitoring"`
+	Monitoring   MonitoringSettings   `mapstructure:"monitoring" linter:"monitoring"`
+	Policy       PolicySettings       `mapstructure:"policy" linter:"policy"`
 }
 
 type OpenAPISettings struct {
 	// EnumFileExcludes contains map with key string contained module name and file path separated by :
-	EnumFileExcludes       map[string][]string `mapstructure:"enum-file-excludes"`
-	HAAbsoluteKeysExcludes map[string]string   `mapstructure:"ha-absolute-keys-excludes"`
+	EnumFileExcludes       map[string][]string `mapstructure:"enum-file-excludes" keyFormat:"module-path-or-any" exclusion:"enum"`
+	HAAbsoluteKeysExcludes map[string]string   `mapstructure:"ha-absolute-keys-excludes" keyFormat:"module-path" exclusion:"ha-https-defaults"`
 	KeyBannedNames         []string            `mapstructure:"key-banned-names"`
+
+	usage *ExclusionUsage
 }
 
 type NoCyrillicSettings struct {
-	NoCyrillicFileExcludes []string `mapstructure:"no-cyrillic-file-excludes"`
+	NoCyrillicFileExcludes []string `mapstructure:"no-cyrillic-file-excludes" format:"module-path" exclusion:"cyrillic-letters"`
 	FileExtensions         []string `mapstructure:"file-extensions"`
 	SkipDocRe              string   `mapstructure:"skip-doc-re"`
 	SkipI18NRe             string   `mapstructure:"skip-i18n-re"`
 	SkipSelfRe             string   `mapstructure:"skip-self-re"`
+
+	usage *ExclusionUsage
 }
 
 type LicenseSettings struct {
-	CopyrightExcludes []string `mapstructure:"copyright-excludes"`
-	SkipOssChecks     []string `mapstructure:"skip-oss-checks"`
+	CopyrightExcludes []string `mapstructure:"copyright-excludes" format:"module-path" exclusion:"copyright"`
+	SkipOssChecks     []string `mapstructure:"skip-oss-checks" exclusion:"oss"`
+
+	usage *ExclusionUsage
 }
 
 type ProbesSettings struct {
-	ProbesExcludes map[string][]string `mapstructure:"probes-excludes"`
+	ProbesExcludes map[string][]string `mapstructure:"probes-excludes" exclusion:"container-probes"`
+
+	usage *ExclusionUsage
 }
 
 type ContainerSettings struct {
-	SkipContainers []string `mapstructure:"skip-containers"`
+	SkipContainers []string `mapstructure:"skip-containers" format:"object-container" exclusion:""`
+
+	usage *ExclusionUsage
 }
 
 type K8SResourcesSettings struct {
-	SkipKubeRbacProxyChecks []string `mapstructure:"skip-kube-rbac-proxy-checks"`
-	SkipContainerChecks     []string `mapstructure:"skip-container-checks"`
-	SkipVPAChecks           []string `mapstructure:"skip-vpa-checks"`
-	SkipPDBChecks           []string `mapstructure:"skip-pdb-checks"`
+	SkipKubeRbacProxyChecks []string `mapstructure:"skip-kube-rbac-proxy-checks" exclusion:"kube-rbac-proxy-ca"`
+	SkipContainerChecks     []string `mapstructure:"skip-container-checks" exclusion:""`
+	SkipVPAChecks           []string `mapstructure:"skip-vpa-checks" format:"namespace-name" exclusion:"vpa"`
+	SkipPDBChecks           []string `mapstructure:"skip-pdb-checks" format:"namespace-name" exclusion:""`
+
+	usage *ExclusionUsage
 }
 
 type ResourcesSettings struct{}
 
 type MonitoringSettings struct {
-	SkipModuleChecks []string `mapstructure:"skip-module-checks"`
+	SkipModuleChecks []string `mapstructure:"skip-module-checks" exclusion:""`
+
+	usage *ExclusionUsage
 }
 
 type RbacSettings struct {
-	SkipCheckWildcards     map[string][]string `mapstructure:"skip-check-wildcards"`
-	SkipModuleCheckBinding []string            `mapstructure:"skip-module-check-binding"`
-	SkipObjectCheckBinding []string            `mapstructure:"skip-object-check-binding"`
+	SkipCheckWildcards     map[string][]string `mapstructure:"skip-check-wildcards" exclusion:"wildcards"`
+	SkipModuleCheckBinding []string            `mapstructure:"skip-module-check-binding" exclusion:"binding-subject"`
+	SkipObjectCheckBinding []string            `mapstructure:"skip-object-check-binding" exclusion:"placement"`
+
+	usage *ExclusionUsage
 }
 
 type HelmSettings struct {
-	SkipModuleImageName      []string `mapstructure:"skip-module-image-name"`
-	SkipDistrolessImageCheck []string `mapstructure:"skip-distroless-image-check"`
-	SkipHelmIgnoreCheck      []string `mapstructure:"skip-helm-ignore-check"`
-	SkipNamespaceCheck       []string `mapstructure:"skip-namespace-check"`
+	SkipModuleImageName      []string `mapstructure:"skip-module-image-name" exclusion:"images"`
+	SkipDistrolessImageCheck []string `mapstructure:"skip-distroless-image-check" exclusion:"images"`
+	SkipHelmIgnoreCheck      []string `mapstructure:"skip-helm-ignore-check" exclusion:"helmignore"`
+	SkipNamespaceCheck       []string `mapstructure:"skip-namespace-check" exclusion:"namespace"`
+
+	usage *ExclusionUsage
+}
+
+type PolicySettings struct {
+	// Paths contains directories and files with Rego policies, relative paths are relative to the config file.
+	Paths []string `mapstructure:"paths"`
 }
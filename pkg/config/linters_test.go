@@ -0,0 +1,100 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLintersConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     LintersConfig
+		linter  string
+		rule    string
+		enabled bool
+	}{
+		{
+			name:    "everything is enabled by default",
+			linter:  "k8s-resources",
+			rule:    "revision-history-limit",
+			enabled: true,
+		},
+		{
+			name:    "disabled linter",
+			cfg:     LintersConfig{Disable: []string{"k8s-resources"}},
+			linter:  "k8s-resources",
+			rule:    "revision-history-limit",
+			enabled: false,
+		},
+		{
+			name:    "disabled rule",
+			cfg:     LintersConfig{Disable: []string{"k8s-resources/revision-history-limit"}},
+			linter:  "k8s-resources",
+			rule:    "revision-history-limit",
+			enabled: false,
+		},
+		{
+			name:    "other rule of the linter is not disabled",
+			cfg:     LintersConfig{Disable: []string{"k8s-resources/revision-history-limit"}},
+			linter:  "k8s-resources",
+			rule:    "dns-policy",
+			enabled: true,
+		},
+		{
+			name:    "linter is not enabled",
+			cfg:     LintersConfig{Enable: []string{"monitoring"}},
+			linter:  "k8s-resources",
+			rule:    "revision-history-limit",
+			enabled: false,
+		},
+		{
+			name:    "only enabled rules of the linter are run",
+			cfg:     LintersConfig{Enable: []string{"k8s-resources/revision-history-limit"}},
+			linter:  "k8s-resources",
+			rule:    "dns-policy",
+			enabled: false,
+		},
+		{
+			name:    "enabled rule",
+			cfg:     LintersConfig{Enable: []string{"k8s-resources/revision-history-limit"}},
+			linter:  "k8s-resources",
+			rule:    "revision-history-limit",
+			enabled: true,
+		},
+		{
+			name:    "findings of dmt are reported with enabled linters",
+			cfg:     LintersConfig{Enable: []string{"container"}},
+			linter:  ToolLinter,
+			rule:    "internal-error",
+			enabled: true,
+		},
+		{
+			name:    "other rules of dmt are reported with an enabled rule of dmt",
+			cfg:     LintersConfig{Enable: []string{"dmt/ignore"}},
+			linter:  ToolLinter,
+			rule:    "internal-error",
+			enabled: true,
+		},
+		{
+			name:    "disabled rule of dmt",
+			cfg:     LintersConfig{Enable: []string{"container"}, Disable: []string{"dmt/internal-error"}},
+			linter:  ToolLinter,
+			rule:    "internal-error",
+			enabled: false,
+		},
+		{
+			name:    "disable takes precedence",
+			cfg:     LintersConfig{Enable: []string{"k8s-resources"}, Disable: []string{"k8s-resources/revision-history-limit"}},
+			linter:  "k8s-resources",
+			rule:    "revision-history-limit",
+			enabled: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.enabled, tt.cfg.IsRuleEnabled(tt.linter, tt.rule))
+		})
+	}
+}
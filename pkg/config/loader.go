@@ -1,11 +1,11 @@
 package config
 
 import (
-	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"slices"
+	"time"
 
 	"github.com/mitchellh/go-homedir"
 	"github.com/mitchellh/mapstructure"
@@ -15,8 +15,22 @@ import (
 	"github.com/deckhouse/dmt/internal/logger"
 )
 
+// configName is the name of config files without an extension.
+const configName = ".dmtlint"
+
+// keyDelimiter separates nested keys in viper, it is not a dot, because keys of exclusions contain file paths.
+const keyDelimiter = "::"
+
 type LoaderOptions struct {
+	// Config is the path of the config file. If it is empty, config files are searched
+	// in the directory, its parents, the working directory and the home directory.
 	Config string
+	// Enable and Disable are added to the linters selectors of config files.
+	Enable  []string
+	Disable []string
+	// AllowRulePacks allows rule packs in all config files. Rule packs run executables, so by default they are
+	// allowed only in the Config file and in the home directory config, not in config files of linted repositories.
+	AllowRulePacks bool
 }
 
 type Loader struct {
@@ -24,67 +38,68 @@ type Loader struct {
 
 	cfg  *Config
 	args []string
+	opts LoaderOptions
 }
 
-func NewLoader(cfg *Config, dirs []string) *Loader {
+func NewLoader(cfg *Config, dirs []string, opts LoaderOptions) *Loader {
 	return &Loader{
-		viper: viper.New(),
+		viper: viper.NewWithOptions(viper.KeyDelimiter(keyDelimiter)),
 		cfg:   cfg,
 		args:  dirs,
+		opts:  opts,
 	}
 }
 
 func (l *Loader) Load() error {
-	err := l.setConfigFile()
-	if err != nil {
-		return err
+	firstArg := "."
+	if len(l.args) > 0 {
+		firstArg = l.args[0]
 	}
 
-	err = l.parseConfig()
-	if err != nil {
-		return err
+	files := l.configFiles(firstArg)
+	for _, file := range files {
+		logger.InfoF("Used config file %s", file)
 	}
 
-	return nil
+	return l.load(files)
 }
 
-func (l *Loader) setConfigFile() error {
-	l.viper.SetConfigName(".dmtlint")
-
-	configSearchPaths := l.getConfigSearchPaths()
+// configFiles returns config files for the directory from the lowest priority to the highest one.
+func (l *Loader) configFiles(dir string) []string {
+	if l.opts.Config != "" {
+		return []string{l.opts.Config}
+	}
 
-	logger.InfoF("Config search paths: %s", configSearchPaths)
+	searchPaths := l.getConfigSearchPaths(dir)
+	logger.DebugF("Config search paths: %s", searchPaths)
 
-	for _, p := range configSearchPaths {
-		l.viper.AddConfigPath(p)
+	var files []string
+	for _, p := range searchPaths {
+		if file := findConfigFile(p); file != "" {
+			files = append(files, file)
+		}
 	}
 
-	return nil
+	return files
 }
 
-func (l *Loader) getConfigSearchPaths() []string {
-	firstArg := "./..."
-	if len(l.args) > 0 {
-		firstArg = l.args[0]
-	}
-
-	absPath, err := filepath.Abs(firstArg)
+// getConfigSearchPaths returns the home directory, the working directory and directories
+// from the root to the directory, settings of later directories override earlier ones.
+func (l *Loader) getConfigSearchPaths(dir string) []string {
+	absPath, err := filepath.Abs(dir)
 	if err != nil {
-		logger.WarnF("Can't make abs path for %q: %s", firstArg, err)
-		absPath = filepath.Clean(firstArg)
+		logger.WarnF("Can't make abs path for %q: %s", dir, err)
+		absPath = filepath.Clean(dir)
 	}
 
 	// start from it
-	var currentDir string
-	if fsutils.IsDir(absPath) {
-		currentDir = absPath
-	} else {
+	currentDir := absPath
+	if !fsutils.IsDir(absPath) {
 		currentDir = filepath.Dir(absPath)
 	}
 
 	// find all dirs from it up to the root
-	searchPaths := []string{"./"}
-
+	var searchPaths []string
 	for {
 		searchPaths = append(searchPaths, currentDir)
 
@@ -95,67 +110,131 @@ func (l *Loader) getConfigSearchPaths() []string {
 
 		currentDir = parent
 	}
+	slices.Reverse(searchPaths)
+
+	if wd, err := os.Getwd(); err != nil {
+		logger.WarnF("Can't get working directory: %v", err)
+	} else if !slices.Contains(searchPaths, wd) {
+		searchPaths = slices.Insert(searchPaths, 0, wd)
+	}
 
 	// find home directory for global config
 	if home, err := homedir.Dir(); err != nil {
 		logger.WarnF("Can't get user's home directory: %v", err)
 	} else if !slices.Contains(searchPaths, home) {
-		searchPaths = append(searchPaths, home)
+		searchPaths = slices.Insert(searchPaths, 0, home)
 	}
 
 	return searchPaths
 }
 
-func (l *Loader) parseConfig() error {
-	if err := l.viper.ReadInConfig(); err != nil {
-		var configFileNotFoundError viper.ConfigFileNotFoundError
-		if errors.As(err, &configFileNotFoundError) {
-			// Load configuration from flags only.
-			err = l.viper.Unmarshal(l.cfg, customDecoderHook())
-			if err != nil {
-				return fmt.Errorf("can't unmarshal config by viper (flags): %w", err)
-			}
-
-			return nil
+// findConfigFile returns the path of the config file in the directory or an empty string if there is no config file.
+func findConfigFile(dir string) string {
+	for _, ext := range viper.SupportedExts {
+		file := filepath.Join(dir, configName+"."+ext)
+		if info, err := os.Stat(file); err == nil && !info.IsDir() {
+			return file
+		}
+	}
+
+	return ""
+}
+
+// load merges config files into the config, settings of later files override earlier ones.
+// Nested maps are merged, other values including lists are replaced.
+func (l *Loader) load(files []string) error {
+	for _, file := range files {
+		settings, exclusions, err := readConfigFile(file)
+		if err != nil {
+			return err
 		}
+		l.cfg.exclusions = append(l.cfg.exclusions, exclusions...)
 
-		return fmt.Errorf("can't read viper config: %w", err)
+		if _, ok := settings[rulePacksKey]; ok && !l.allowsRulePacks(file) {
+			return fmt.Errorf("%s: rule packs are allowed only in the config file set by --config and in the home directory config, "+
+				"use --allow-rule-packs to run rule packs of other config files", file)
+		}
+
+		if err = l.viper.MergeConfigMap(settings); err != nil {
+			return fmt.Errorf("can't merge config %s: %w", file, err)
+		}
 	}
 
-	err := l.setConfigDir()
-	if err != nil {
+	if err := l.viper.Unmarshal(l.cfg, customDecoderHook()); err != nil {
+		return fmt.Errorf("can't unmarshal config by viper: %w", err)
+	}
+
+	l.cfg.LintersSettings.setUsage(l.cfg.usage)
+	l.cfg.files = files
+	l.cfg.opts = l.opts
+	if len(files) > 0 {
+		usedConfigDir, err := filepath.Abs(filepath.Dir(files[len(files)-1]))
+		if err != nil {
+			return fmt.Errorf("can't get config directory: %w", err)
+		}
+
+		l.cfg.cfgDir = usedConfigDir
+	}
+
+	l.cfg.Linters.Enable = append(l.cfg.Linters.Enable, l.opts.Enable...)
+	l.cfg.Linters.Disable = append(l.cfg.Linters.Disable, l.opts.Disable...)
+
+	if err := l.cfg.compileSeverityOverrides(); err != nil {
 		return err
 	}
 
-	// Load configuration from all sources (flags, file).
-	if err = l.viper.Unmarshal(l.cfg, customDecoderHook()); err != nil {
-		return fmt.Errorf("can't unmarshal config by viper (flags, file): %w", err)
+	return l.cfg.compileCustomRules()
+}
+
+// allowsRulePacks reports whether rule packs of the config file can be run.
+func (l *Loader) allowsRulePacks(file string) bool {
+	if l.opts.AllowRulePacks {
+		return true
+	}
+
+	path, err := filepath.Abs(file)
+	if err != nil {
+		return false
 	}
 
-	return nil
+	if l.opts.Config != "" {
+		if config, err := filepath.Abs(l.opts.Config); err == nil && config == path {
+			return true
+		}
+	}
+
+	home, err := homedir.Dir()
+
+	return err == nil && filepath.Dir(path) == filepath.Clean(home)
 }
 
-func (l *Loader) setConfigDir() error {
-	usedConfigFile := l.viper.ConfigFileUsed()
-	if usedConfigFile == "" {
-		return nil
+// readConfigFile reads settings from the config file and validates them against the schema.
+// Exclusion entries with details are replaced by their values, expired entries are dropped.
+func readConfigFile(file string) (map[string]any, []ExclusionEntry, error) {
+	v := viper.NewWithOptions(viper.KeyDelimiter(keyDelimiter))
+	if filepath.Ext(file) == "" {
+		v.SetConfigType("yaml")
 	}
 
-	if usedConfigFile == os.Stdin.Name() {
-		usedConfigFile = ""
-		logger.InfoF("Reading config file stdin")
+	v.SetConfigFile(file)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, nil, fmt.Errorf("can't read config %s: %w", file, err)
 	}
 
-	logger.InfoF("Used config file %s", usedConfigFile)
+	settings := formatDates(v.AllSettings()).(map[string]any)
+	if err := validateSettings(settings); err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", file, err)
+	}
 
-	usedConfigDir, err := filepath.Abs(filepath.Dir(usedConfigFile))
+	resolveRulePacks(file, settings)
+	resolvePolicyPaths(file, settings)
+
+	exclusions, err := resolveExclusions(file, settings, time.Now())
 	if err != nil {
-		return errors.New("can't get config directory")
+		return nil, nil, err
 	}
 
-	l.cfg.cfgDir = usedConfigDir
-
-	return nil
+	return settings, exclusions, nil
 }
 
 func customDecoderHook() viper.DecoderConfigOption {
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/logger"
+)
+
+func TestMain(m *testing.M) {
+	logger.InitLogger("ERROR")
+	os.Exit(m.Run())
+}
+
+func writeConfig(t *testing.T, dir, content string) string {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+	path := filepath.Join(dir, ".dmtlint.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestConfig_ForModule(t *testing.T) {
+	root := t.TempDir()
+	repoConfig := writeConfig(t, root, `
+linters:
+  disable: [monitoring]
+linters-settings:
+  container:
+    skip-containers: [module-a:app]
+`)
+	moduleConfig := writeConfig(t, filepath.Join(root, "module-a"), `
+linters-settings:
+  license:
+    skip-oss-checks: [module-a]
+`)
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "module-b"), 0o755))
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{Enable: []string{"container"}})
+	require.NoError(t, err)
+	require.Equal(t, []string{repoConfig}, cfg.Files())
+
+	moduleA, err := cfg.ForModule(filepath.Join(root, "module-a"))
+	require.NoError(t, err)
+	require.Equal(t, []string{repoConfig, moduleConfig}, moduleA.Files())
+	require.Equal(t, []string{"module-a:app"}, moduleA.LintersSettings.Container.SkipContainers)
+	require.Equal(t, []string{"module-a"}, moduleA.LintersSettings.License.SkipOssChecks)
+	require.Equal(t, []string{"monitoring"}, moduleA.Linters.Disable)
+	require.Equal(t, []string{"container"}, moduleA.Linters.Enable)
+
+	cached, err := cfg.ForModule(filepath.Join(root, "module-a"))
+	require.NoError(t, err)
+	require.Same(t, moduleA, cached)
+
+	// modules without their own config files use the repository config
+	moduleB, err := cfg.ForModule(filepath.Join(root, "module-b"))
+	require.NoError(t, err)
+	require.Same(t, cfg, moduleB)
+	require.Empty(t, moduleB.LintersSettings.License.SkipOssChecks)
+}
+
+func TestConfig_ForModule_explicitConfig(t *testing.T) {
+	root := t.TempDir()
+	writeConfig(t, root, `
+linters:
+  disable: [monitoring]
+`)
+	explicit := filepath.Join(t.TempDir(), "dmt.yaml")
+	require.NoError(t, os.WriteFile(explicit, []byte("linters:\n  disable: [probes]\n"), 0o600))
+	moduleConfig := writeConfig(t, filepath.Join(root, "module-a"), `
+warnings-only: [vpa]
+`)
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{Config: explicit})
+	require.NoError(t, err)
+	require.Equal(t, []string{"probes"}, cfg.Linters.Disable)
+
+	moduleA, err := cfg.ForModule(filepath.Join(root, "module-a"))
+	require.NoError(t, err)
+	require.Equal(t, []string{explicit, moduleConfig}, moduleA.Files())
+	require.Equal(t, []string{"probes"}, moduleA.Linters.Disable)
+	require.Equal(t, []string{"vpa"}, moduleA.WarningsOnly)
+}
+
+func TestConfig_RulePacks(t *testing.T) {
+	const packs = `
+rule-packs:
+  - command: ./tools/acme-rules
+`
+	root := t.TempDir()
+	repoConfig := writeConfig(t, root, packs)
+
+	// rule packs of repository config files are not run by default
+	_, err := NewDefault([]string{root}, LoaderOptions{})
+	require.ErrorContains(t, err, repoConfig+": rule packs are allowed only")
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{AllowRulePacks: true})
+	require.NoError(t, err)
+	require.Equal(t, []RulePack{{Command: filepath.Join(root, "tools", "acme-rules")}}, cfg.RulePacks)
+
+	explicit := filepath.Join(t.TempDir(), "dmt.yaml")
+	require.NoError(t, os.WriteFile(explicit, []byte(packs), 0o600))
+	cfg, err = NewDefault([]string{root}, LoaderOptions{Config: explicit})
+	require.NoError(t, err)
+	require.Len(t, cfg.RulePacks, 1)
+
+	// the module config is merged into the explicit one, but its rule packs are not run
+	writeConfig(t, filepath.Join(root, "module-a"), packs)
+	_, err = cfg.ForModule(filepath.Join(root, "module-a"))
+	require.ErrorContains(t, err, "rule packs are allowed only")
+}
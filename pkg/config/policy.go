@@ -0,0 +1,23 @@
+package config
+
+import (
+	"path/filepath"
+)
+
+// resolvePolicyPaths makes relative paths of Rego policies in settings of the config file absolute.
+func resolvePolicyPaths(file string, settings map[string]any) {
+	linters, _ := settings[lintersSettingsKey].(map[string]any)
+	policy, _ := linters["policy"].(map[string]any)
+	paths, _ := policy["paths"].([]any)
+
+	dir, err := filepath.Abs(filepath.Dir(file))
+	if err != nil {
+		return
+	}
+
+	for i, item := range paths {
+		if path, ok := item.(string); ok && path != "" && !filepath.IsAbs(path) {
+			paths[i] = filepath.Join(dir, path)
+		}
+	}
+}
@@ -0,0 +1,21 @@
+package config
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_policyPaths(t *testing.T) {
+	root := t.TempDir()
+	writeConfig(t, root, `
+linters-settings:
+  policy:
+    paths: [policies, /etc/dmt/policies]
+`)
+
+	cfg, err := NewDefault([]string{root}, LoaderOptions{})
+	require.NoError(t, err)
+	require.Equal(t, []string{filepath.Join(root, "policies"), "/etc/dmt/policies"}, cfg.LintersSettings.Policy.Paths)
+}
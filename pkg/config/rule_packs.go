@@ -0,0 +1,39 @@
+package config
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// rulePacksKey is the key of rule packs in config files.
+const rulePacksKey = "rule-packs"
+
+// RulePack is an external executable which lints modules, see the rulepack package for the protocol.
+type RulePack struct {
+	// Command is the executable of the rule pack. A relative path with a directory is relative to the config file,
+	// a name without a directory is searched in PATH.
+	Command string   `mapstructure:"command" required:"true"`
+	Args    []string `mapstructure:"args"`
+	// Settings are passed to the rule pack with every module.
+	Settings map[string]any `mapstructure:"settings"`
+}
+
+// resolveRulePacks makes relative commands of rule packs in settings of the config file absolute.
+func resolveRulePacks(file string, settings map[string]any) {
+	packs, _ := settings[rulePacksKey].([]any)
+	for _, item := range packs {
+		pack, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+
+		command, _ := pack["command"].(string)
+		if command == "" || filepath.IsAbs(command) || !strings.ContainsRune(filepath.ToSlash(command), '/') {
+			continue
+		}
+
+		if dir, err := filepath.Abs(filepath.Dir(file)); err == nil {
+			pack["command"] = filepath.Join(dir, command)
+		}
+	}
+}
@@ -0,0 +1,279 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/xeipuuv/gojsonschema"
+)
+
+// entryFormat is the format of exclusion entries, it is set by the "format" tag for lists
+// and by the "keyFormat" tag for map keys.
+type entryFormat struct {
+	pattern string
+	display string
+}
+
+var entryFormats = map[string]entryFormat{
+	"module-path":        {pattern: `^[^:/\s]+:/\S+$`, display: "<module>:/<path in the module>"},
+	"module-path-or-any": {pattern: `^(\*|[^:/\s]+:/\S+)$`, display: "<module>:/<path in the module> or *"},
+	"object-container":   {pattern: `^[^:]+:[^:]+$`, display: "<object name>:<container>"},
+	"namespace-name":     {pattern: `^[a-z0-9]([-a-z0-9]*[a-z0-9])?:[^:]+$`, display: "<namespace>:<name>"},
+}
+
+// Schema returns the JSON Schema of config files generated from the Config type.
+func Schema() map[string]any {
+	schema := typeSchema(reflect.TypeOf(Config{}))
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = "dmt config"
+
+	return schema
+}
+
+func typeSchema(t reflect.Type) map[string]any {
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]any)
+		var required []any
+		for i := range t.NumField() {
+			field := t.Field(i)
+			name := field.Tag.Get("mapstructure")
+			if !field.IsExported() || name == "" {
+				continue
+			}
+			properties[name] = fieldSchema(field)
+			if field.Tag.Get("required") == "true" {
+				required = append(required, name)
+			}
+		}
+
+		schema := map[string]any{"type": "object", "properties": properties, "additionalProperties": false}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+
+		return schema
+	case reflect.Slice:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem())}
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int32, reflect.Int64:
+		return map[string]any{"type": "integer"}
+	case reflect.Interface:
+		return map[string]any{}
+	default:
+		return map[string]any{"type": "string"}
+	}
+}
+
+func fieldSchema(field reflect.StructField) map[string]any {
+	schema := typeSchema(field.Type)
+
+	if enum := field.Tag.Get("enum"); enum != "" {
+		schema["enum"] = strings.Split(enum, ",")
+	}
+
+	if format, ok := entryFormats[field.Tag.Get("format")]; ok {
+		items := schema
+		if field.Type.Kind() == reflect.Slice {
+			items = schema["items"].(map[string]any)
+		}
+		items["pattern"] = format.pattern
+		schema["description"] = "Entries have the " + format.display + " format"
+	}
+
+	if format, ok := entryFormats[field.Tag.Get("keyFormat")]; ok {
+		schema["propertyNames"] = map[string]any{"pattern": format.pattern}
+		schema["description"] = "Keys have the " + format.display + " format"
+	}
+
+	if _, ok := field.Tag.Lookup("exclusion"); ok {
+		schema = withEntryDetails(schema)
+	}
+
+	if field.Type.Kind() == reflect.Map {
+		// a list of maps is merged into a single map
+		return map[string]any{"anyOf": []any{schema, map[string]any{"type": "array", "items": schema}}}
+	}
+
+	return schema
+}
+
+// withEntryDetails allows exclusion entries to be objects with the value and optional details.
+func withEntryDetails(schema map[string]any) map[string]any {
+	switch schema["type"] {
+	case "array":
+		schema["items"] = withEntryDetails(schema["items"].(map[string]any))
+	case "object":
+		schema["additionalProperties"] = withEntryDetails(schema["additionalProperties"].(map[string]any))
+	case "string":
+		value := map[string]any{"type": "string"}
+		if pattern, ok := schema["pattern"]; ok {
+			value["pattern"] = pattern
+		}
+
+		return map[string]any{"anyOf": []any{schema, map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"value":  value,
+				"reason": map[string]any{"type": "string"},
+				"until":  map[string]any{"type": "string", "format": "date"},
+				"issue":  map[string]any{"type": "string"},
+			},
+			"required":             []any{"value"},
+			"additionalProperties": false,
+		}}}
+	}
+
+	return schema
+}
+
+var compiledSchema = sync.OnceValues(func() (*gojsonschema.Schema, error) {
+	return gojsonschema.NewSchema(gojsonschema.NewGoLoader(Schema()))
+})
+
+// validateSettings validates settings read from a config file against the schema.
+func validateSettings(settings map[string]any) error {
+	schema, err := compiledSchema()
+	if err != nil {
+		return fmt.Errorf("compile config schema: %w", err)
+	}
+
+	result, err := schema.Validate(gojsonschema.NewGoLoader(settings))
+	if err != nil {
+		return err
+	}
+
+	if result.Valid() {
+		return nil
+	}
+
+	var messages []string
+	for _, e := range result.Errors() {
+		// nested errors are more specific, they are reported instead
+		if e.Type() == "number_any_of" || e.Type() == "invalid_property_name" {
+			continue
+		}
+		messages = append(messages, fmt.Sprintf("%s: %s", e.Field(), errorMessage(e)))
+	}
+	slices.Sort(messages)
+
+	return fmt.Errorf("invalid config: %s", strings.Join(slices.Compact(messages), "; "))
+}
+
+func errorMessage(e gojsonschema.ResultError) string {
+	switch e.Type() {
+	case "additional_property_not_allowed":
+		property := fmt.Sprint(e.Details()["property"])
+		message := fmt.Sprintf("unknown key %q", property)
+		if suggestion := closestKey(schemaAt(Schema(), e.Field()), property); suggestion != "" {
+			message += fmt.Sprintf(", did you mean %q?", suggestion)
+		}
+
+		return message
+	case "enum":
+		return fmt.Sprintf("%q is not one of %s", e.Value(), e.Details()["allowed"])
+	case "pattern":
+		pattern := fmt.Sprint(e.Details()["pattern"])
+		for _, format := range entryFormats {
+			if format.pattern == pattern {
+				return fmt.Sprintf("%q does not match the %s format", e.Value(), format.display)
+			}
+		}
+	}
+
+	return e.Description()
+}
+
+// schemaAt returns the schema of the field, the field is a path of keys and list indexes separated by dots.
+func schemaAt(schema map[string]any, field string) map[string]any {
+	if field == "(root)" {
+		return schema
+	}
+
+	for _, key := range strings.Split(field, ".") {
+		schema = objectAlternative(schema)
+
+		if _, err := strconv.Atoi(key); err == nil {
+			if items, ok := schema["items"].(map[string]any); ok {
+				schema = items
+				continue
+			}
+		}
+
+		properties, ok := schema["properties"].(map[string]any)
+		if !ok {
+			return nil
+		}
+		if schema, ok = properties[key].(map[string]any); !ok {
+			return nil
+		}
+	}
+
+	return objectAlternative(schema)
+}
+
+// objectAlternative returns the first object alternative of the "anyOf" schema or the schema itself.
+func objectAlternative(schema map[string]any) map[string]any {
+	anyOf, ok := schema["anyOf"].([]any)
+	if !ok {
+		return schema
+	}
+
+	for _, alternative := range anyOf {
+		if alternative := alternative.(map[string]any); alternative["type"] == "object" {
+			return alternative
+		}
+	}
+
+	return anyOf[0].(map[string]any)
+}
+
+// closestKey returns a known key of the object schema which is similar to the key.
+func closestKey(schema map[string]any, key string) string {
+	properties, ok := schema["properties"].(map[string]any)
+	if !ok {
+		return ""
+	}
+
+	const maxDistance = 3
+
+	var result string
+	best := maxDistance + 1
+	for property := range properties {
+		if d := distance(key, property); d < best || (d == best && property < result) {
+			result, best = property, d
+		}
+	}
+
+	return result
+}
+
+// distance returns the Levenshtein distance between strings.
+func distance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		curr := make([]int, len(b)+1)
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev = curr
+	}
+
+	return prev[len(b)]
+}
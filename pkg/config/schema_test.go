@@ -0,0 +1,111 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadConfigFile(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		errors  []string
+	}{
+		{
+			name: "valid config",
+			content: `
+linters-settings:
+  openapi:
+    enum-file-excludes:
+      - prometheus:/openapi/values.yaml:
+          - properties.internal.properties.grafana
+  license:
+    copyright-excludes:
+      - upmeter:/images/upmeter/stress.sh
+  k8s_resources:
+    skip-vpa-checks:
+      - d8-system:deckhouse
+severity-overrides:
+  - severity: warning
+    rules: [monitoring]
+warnings-only:
+  - openapi
+`,
+		},
+		{
+			name: "unknown keys",
+			content: `
+linters-setting:
+  probes:
+    probes-excludes:
+      d8-istio: [operator]
+linters-settings:
+  k8s-resources:
+    skip-vpa-checks: [d8-system:deckhouse]
+`,
+			errors: []string{
+				`(root): unknown key "linters-setting", did you mean "linters-settings"?`,
+				`linters-settings: unknown key "k8s-resources", did you mean "k8s_resources"?`,
+			},
+		},
+		{
+			name: "wrong types and formats",
+			content: `
+warnings-only: openapi
+linters-settings:
+  license:
+    copyright-excludes:
+      - upmeter/images/upmeter/stress.sh
+  container:
+    skip-containers:
+      - okagent
+severity-overrides:
+  - severity: fatal
+`,
+			errors: []string{
+				`warnings-only: Invalid type. Expected: array, given: string`,
+				`linters-settings.license.copyright-excludes.0: "upmeter/images/upmeter/stress.sh" does not match the <module>:/<path in the module> format`,
+				`linters-settings.container.skip-containers.0: "okagent" does not match the <object name>:<container> format`,
+				`severity-overrides.0.severity: "fatal" is not one of "error", "warning", "info"`,
+			},
+		},
+		{
+			name: "exclusion details",
+			content: `
+linters-settings:
+  license:
+    copyright-excludes:
+      - value: upmeter:/images/upmeter/stress.sh
+        reasn: taken from the upstream
+        until: 2026-31-12
+      - reason: no value
+`,
+			errors: []string{
+				`linters-settings.license.copyright-excludes.0.until: Does not match format 'date'`,
+				`linters-settings.license.copyright-excludes.0: unknown key "reasn", did you mean "reason"?`,
+				`linters-settings.license.copyright-excludes.1: value is required`,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), ".dmtlint.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, _, err := readConfigFile(path)
+			if len(tt.errors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, e := range tt.errors {
+				require.Contains(t, err.Error(), e)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/gobwas/glob"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// SeverityOverride changes the severity of errors which match all its conditions, empty conditions match everything.
+type SeverityOverride struct {
+	Severity string `mapstructure:"severity" enum:"error,warning,info"`
+	// Rules contains linter names ("monitoring") or rules of linters ("k8s-resources/revision-history-limit").
+	Rules []string `mapstructure:"rules"`
+	// Modules contains globs of module names.
+	Modules []string `mapstructure:"modules"`
+	// Paths contains globs of file paths relative to the module root, "**" matches any number of directories.
+	Paths []string `mapstructure:"paths"`
+
+	severity errors.Severity
+	modules  []glob.Glob
+	paths    []glob.Glob
+}
+
+func (o *SeverityOverride) compile() error {
+	var err error
+
+	o.severity, err = errors.ParseSeverity(o.Severity)
+	if err != nil {
+		return err
+	}
+
+	o.modules, err = compileGlobs(o.Modules)
+	if err != nil {
+		return err
+	}
+
+	o.paths, err = compileGlobs(o.Paths, '/')
+
+	return err
+}
+
+func compileGlobs(patterns []string, separators ...rune) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, separators...)
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob %q: %w", pattern, err)
+		}
+		globs = append(globs, g)
+	}
+
+	return globs, nil
+}
+
+func (o *SeverityOverride) matches(e *errors.LintRuleError) bool {
+	if len(o.Rules) > 0 && !slices.ContainsFunc(o.Rules, func(selector string) bool {
+		linter, rule := SplitSelector(selector)
+		return linter == e.Linter && (rule == "" || rule == e.Rule)
+	}) {
+		return false
+	}
+
+	if len(o.modules) > 0 && !matchAny(o.modules, e.Module) {
+		return false
+	}
+
+	if len(o.paths) > 0 && (e.Location.File == "" || !matchAny(o.paths, e.Location.File)) {
+		return false
+	}
+
+	return true
+}
+
+func matchAny(globs []glob.Glob, s string) bool {
+	return slices.ContainsFunc(globs, func(g glob.Glob) bool {
+		return g.Match(s)
+	})
+}
+
+// compileSeverityOverrides validates severity overrides and prepares them for matching.
+func (c *Config) compileSeverityOverrides() error {
+	for i := range c.SeverityOverrides {
+		if err := c.SeverityOverrides[i].compile(); err != nil {
+			return fmt.Errorf("severity-overrides[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// ApplySeverity changes the severity of the error according to warnings-only and severity overrides,
+// the last matching override wins.
+func (c *Config) ApplySeverity(e *errors.LintRuleError) {
+	if slices.Contains(c.WarningsOnly, e.ID) {
+		e.Severity = errors.SeverityWarning
+	}
+
+	for i := range c.SeverityOverrides {
+		if c.SeverityOverrides[i].matches(e) {
+			e.Severity = c.SeverityOverrides[i].severity
+		}
+	}
+}
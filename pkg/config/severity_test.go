@@ -0,0 +1,70 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestConfig_ApplySeverity(t *testing.T) {
+	cfg := &Config{
+		WarningsOnly: []string{"vpa"},
+		SeverityOverrides: []SeverityOverride{
+			{Severity: "info", Rules: []string{"k8s-resources"}, Modules: []string{"test-*"}},
+			{Severity: "error", Rules: []string{"k8s-resources/pdb"}, Paths: []string{"templates/**/controller.yaml"}},
+		},
+	}
+	require.NoError(t, cfg.compileSeverityOverrides())
+
+	newError := func(id, module, file string) *errors.LintRuleError {
+		e := errors.NewLintRuleError(id, "kind = Deployment ; name = app", module, nil, "message").
+			WithRule(id).WithFilePath(file).WithSeverity(errors.SeverityError)
+		e.Linter = "k8s-resources"
+
+		return e
+	}
+
+	tests := []struct {
+		name     string
+		err      *errors.LintRuleError
+		severity errors.Severity
+	}{
+		{
+			name:     "default severity is kept",
+			err:      newError("pdb", "module", "templates/app.yaml"),
+			severity: errors.SeverityError,
+		},
+		{
+			name:     "warnings-only",
+			err:      newError("vpa", "module", "templates/app.yaml"),
+			severity: errors.SeverityWarning,
+		},
+		{
+			name:     "module glob",
+			err:      newError("vpa", "test-module", "templates/app.yaml"),
+			severity: errors.SeverityInfo,
+		},
+		{
+			name:     "the last matching override wins",
+			err:      newError("pdb", "test-module", "templates/controller/controller.yaml"),
+			severity: errors.SeverityError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg.ApplySeverity(tt.err)
+			require.Equal(t, tt.severity, tt.err.Severity)
+		})
+	}
+}
+
+func TestConfig_compileSeverityOverrides(t *testing.T) {
+	cfg := &Config{SeverityOverrides: []SeverityOverride{{Severity: "fatal"}}}
+	require.ErrorContains(t, cfg.compileSeverityOverrides(), "unknown severity")
+
+	cfg = &Config{SeverityOverrides: []SeverityOverride{{Severity: "warning", Paths: []string{"templates/["}}}}
+	require.ErrorContains(t, cfg.compileSeverityOverrides(), "invalid glob")
+}
@@ -0,0 +1,138 @@
+// Package dmt lints Deckhouse modules, it is the API for embedding dmt in other tools.
+//
+//	cfg, err := config.NewDefault(paths, config.LoaderOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	result, err := dmt.Lint(ctx, cfg, paths, dmt.Options{})
+//	if err != nil {
+//		return err
+//	}
+//	if result.Findings.HasSeverity(errors.SeverityError) {
+//		...
+//	}
+//
+// Settings of linters are taken from the config only, so runs with different configs can be done concurrently.
+package dmt
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/deckhouse/dmt/internal/cache"
+	"github.com/deckhouse/dmt/internal/manager"
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Options are options of a lint run.
+type Options struct {
+	// Parallel is the number of modules loading and the number of linters running at the same time,
+	// it is 10 if it is not set.
+	Parallel int
+	// Timeout limits the run of a linter on a module, there is no limit if it is not set.
+	// Linters which time out or panic are reported as "dmt/internal-error" findings unless the rule is disabled.
+	Timeout time.Duration
+	// CacheDir is the directory of the cache of linted modules, unchanged modules are not linted again.
+	// There is no cache if it is not set. Exclusions which matched findings of cached modules are not recorded.
+	CacheDir string
+	// ChangedFiles are absolute paths of changed files. If it is not nil, only modules affected by them are linted:
+	// modules with changed files in their directories, changed config files or images digests.
+	// Exclusions of modules which are not linted are not recorded, so they look unused in such runs.
+	ChangedFiles []string
+	// ValuesFiles are values files every module is linted with in addition to generated values,
+	// every file is a separate scenario. Modules are linted with combinations of their values_matrix_test.yaml as well.
+	// Findings found only with scenarios have their names in errors.LintRuleError.Scenarios.
+	ValuesFiles []string
+}
+
+// Module is a linted module.
+type Module struct {
+	Name      string
+	Namespace string
+	// Path is the directory of the module.
+	Path string
+	// Cached is set if findings of the module are restored from the cache.
+	Cached bool
+}
+
+// Linter is a linter enabled for any of the linted modules.
+type Linter struct {
+	Name        string
+	Description string
+	Rules       []linters.Rule
+}
+
+// LinterFactory creates a linter with settings from the config of the linted modules.
+type LinterFactory func(cfg *config.Config) linters.Linter
+
+// RegisterLinter adds the linter to all lint runs, it is usually called from init of the package with the linter.
+// The name is the lower-case name of the linter.
+// The linter is enabled, disabled and overridden by the name like built-in linters.
+// It panics if a linter with the name is already registered.
+func RegisterLinter(name string, factory LinterFactory) {
+	manager.Register(name, func(cfg *config.Config) manager.Linter { return manager.Public(factory(cfg)) })
+}
+
+// Timings contains durations of phases of a lint run.
+type Timings = manager.Timings
+
+// Result is the result of a lint run.
+type Result struct {
+	// Findings are errors found in modules and config files, their severities are already set.
+	Findings errors.LintRuleErrorsList
+	Modules  []Module
+	Linters  []Linter
+	Timings  Timings
+}
+
+// Lint finds modules in the paths and lints them. Modules which can't be loaded are skipped.
+// Exclusions of the config which matched are recorded in it, see config.Config.UnusedExclusions.
+// If the context is canceled while linters are running, the result contains findings of finished linters
+// and the error of the context is returned with it.
+func Lint(ctx context.Context, cfg *config.Config, paths []string, opts Options) (*Result, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	mng, err := manager.NewManager(paths, cfg)
+	if err != nil {
+		return nil, err
+	}
+	mng.Parallel = opts.Parallel
+	mng.Timeout = opts.Timeout
+	if opts.CacheDir != "" {
+		mng.Cache = cache.New(opts.CacheDir)
+	}
+	if mng.Scenarios, err = module.ReadScenarios(opts.ValuesFiles); err != nil {
+		return nil, err
+	}
+	if opts.ChangedFiles != nil {
+		mng.KeepChanged(opts.ChangedFiles)
+	}
+
+	if err = ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := &Result{Findings: mng.Run(ctx)}
+	result.Timings = mng.Timings
+
+	for _, m := range mng.Modules {
+		result.Modules = append(result.Modules, Module{
+			Name:      m.GetName(),
+			Namespace: m.GetNamespace(),
+			Path:      m.GetPath(),
+			Cached:    slices.Contains(mng.Cached, m.GetName()),
+		})
+	}
+
+	for _, l := range mng.Linters {
+		result.Linters = append(result.Linters, Linter{Name: l.Name(), Description: l.Desc(), Rules: l.Rules()})
+	}
+
+	return result, ctx.Err()
+}
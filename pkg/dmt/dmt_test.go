@@ -0,0 +1,348 @@
+package dmt
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+	"github.com/deckhouse/dmt/pkg/linters/container"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+// writeModule writes a module with a deployment to the directory and returns its path.
+func writeModule(t *testing.T, dir, name string) string {
+	t.Helper()
+
+	modulePath := filepath.Join(dir, name)
+	writeFile(t, filepath.Join(modulePath, "Chart.yaml"), "name: "+name+"\nversion: 0.1.0\n")
+	writeFile(t, filepath.Join(modulePath, "module.yaml"), "name: "+name+"\n")
+	writeFile(t, filepath.Join(modulePath, ".namespace"), "d8-"+name+"\n")
+	writeFile(t, filepath.Join(modulePath, "openapi", "values.yaml"), "type: object\n")
+	writeFile(t, filepath.Join(modulePath, "templates", "deployment.yaml"), `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-`+name+`
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          image: nginx
+`)
+
+	return modulePath
+}
+
+func TestLint_ConcurrentConfigs(t *testing.T) {
+	dir := t.TempDir()
+	modulePath := writeModule(t, dir, "test-module")
+
+	writeFile(t, filepath.Join(dir, "all.yaml"), "linters:\n  enable: [container]\n")
+	writeFile(t, filepath.Join(dir, "skip.yaml"), `linters:
+  enable: [container]
+linters-settings:
+  container:
+    skip-containers: ["app:app"]
+`)
+
+	lint := func(configFile string) *Result {
+		cfg, err := config.NewDefault([]string{modulePath}, config.LoaderOptions{Config: filepath.Join(dir, configFile)})
+		require.NoError(t, err)
+
+		result, err := Lint(context.Background(), cfg, []string{modulePath}, Options{})
+		require.NoError(t, err)
+
+		return result
+	}
+
+	var (
+		wg        sync.WaitGroup
+		all, skip *Result
+	)
+	for range 5 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			all = lint("all.yaml")
+		}()
+		go func() {
+			defer wg.Done()
+			skip = lint("skip.yaml")
+		}()
+		wg.Wait()
+
+		require.Equal(t, []Module{{Name: "test-module", Namespace: "d8-test-module", Path: modulePath}}, all.Modules)
+		require.Len(t, all.Linters, 1)
+		require.Equal(t, container.ID, all.Linters[0].Name)
+
+		// the container is skipped only by the second config
+		require.NotEmpty(t, all.Findings.GetErrors())
+		require.Empty(t, skip.Findings.GetErrors())
+	}
+}
+
+func TestLint_Canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err := Lint(ctx, &config.Config{}, []string{t.TempDir()}, Options{})
+	require.ErrorIs(t, err, context.Canceled)
+}
+
+func TestLint_Modules(t *testing.T) {
+	dir := t.TempDir()
+	names := []string{"module-a", "module-b", "module-c", "module-d"}
+	for _, name := range names {
+		writeModule(t, dir, name)
+	}
+	writeFile(t, filepath.Join(dir, ".dmtlint.yaml"), "linters:\n  enable: [container]\n")
+
+	cfg, err := config.NewDefault([]string{dir}, config.LoaderOptions{})
+	require.NoError(t, err)
+
+	result, err := Lint(context.Background(), cfg, []string{dir}, Options{Parallel: 2})
+	require.NoError(t, err)
+
+	// modules are loaded concurrently, but they are returned in the order they are found
+	require.Len(t, result.Modules, len(names))
+	for i, name := range names {
+		require.Equal(t, name, result.Modules[i].Name)
+	}
+
+	findings := make(map[string]int)
+	for _, e := range result.Findings.GetErrors() {
+		findings[e.Module]++
+	}
+	require.Len(t, findings, len(names))
+
+	require.Len(t, result.Timings.Modules, len(names))
+	require.Contains(t, result.Timings.Linters, container.ID)
+	require.GreaterOrEqual(t, result.Timings.Linting, result.Timings.Loading)
+}
+
+func TestLint_Cache(t *testing.T) {
+	dir := t.TempDir()
+	modulePath := writeModule(t, dir, "module-a")
+	writeModule(t, dir, "module-b")
+	writeFile(t, filepath.Join(dir, ".dmtlint.yaml"), "linters:\n  enable: [container]\n")
+
+	opts := Options{CacheDir: t.TempDir()}
+	lint := func() *Result {
+		cfg, err := config.NewDefault([]string{dir}, config.LoaderOptions{})
+		require.NoError(t, err)
+
+		result, err := Lint(context.Background(), cfg, []string{dir}, opts)
+		require.NoError(t, err)
+
+		return result
+	}
+
+	first := lint()
+	require.False(t, first.Modules[0].Cached)
+	require.False(t, first.Modules[1].Cached)
+
+	second := lint()
+	require.True(t, second.Modules[0].Cached)
+	require.True(t, second.Modules[1].Cached)
+	require.Len(t, second.Findings.GetErrors(), first.Findings.Len())
+	for i, e := range second.Findings.GetErrors() {
+		expected := first.Findings.GetErrors()[i]
+		require.Equal(t, expected.Text, e.Text)
+		require.Equal(t, expected.Rule, e.Rule)
+		require.Equal(t, expected.Severity, e.Severity)
+		require.Equal(t, expected.Location, e.Location)
+		require.Equal(t, fmt.Sprintf("%v", expected.Value), fmt.Sprintf("%v", e.Value))
+	}
+
+	// only the changed module is linted again
+	writeFile(t, filepath.Join(modulePath, "templates", "service.yaml"), "apiVersion: v1\nkind: Service\nmetadata:\n  name: app\n")
+	third := lint()
+	require.False(t, third.Modules[0].Cached)
+	require.True(t, third.Modules[1].Cached)
+
+	// the config is a part of the key
+	writeFile(t, filepath.Join(dir, ".dmtlint.yaml"), "linters:\n  enable: [container, k8s-resources]\n")
+	fourth := lint()
+	require.False(t, fourth.Modules[0].Cached)
+	require.False(t, fourth.Modules[1].Cached)
+}
+
+func TestLint_ChangedFiles(t *testing.T) {
+	dir := t.TempDir()
+	modulePath := writeModule(t, dir, "module-a")
+	writeModule(t, dir, "module-b")
+	configPath := filepath.Join(dir, ".dmtlint.yaml")
+	writeFile(t, configPath, "linters:\n  enable: [container]\n")
+
+	lint := func(changed []string) []string {
+		cfg, err := config.NewDefault([]string{dir}, config.LoaderOptions{})
+		require.NoError(t, err)
+
+		result, err := Lint(context.Background(), cfg, []string{dir}, Options{ChangedFiles: changed})
+		require.NoError(t, err)
+
+		var names []string
+		for _, m := range result.Modules {
+			names = append(names, m.Name)
+		}
+
+		return names
+	}
+
+	require.Equal(t, []string{"module-a"}, lint([]string{filepath.Join(modulePath, "templates", "deployment.yaml")}))
+	// config files affect all their modules
+	require.Equal(t, []string{"module-a", "module-b"}, lint([]string{configPath}))
+	require.Empty(t, lint([]string{filepath.Join(dir, "README.md")}))
+	require.Equal(t, []string{"module-a", "module-b"}, lint(nil))
+}
+
+func TestLint_Scenarios(t *testing.T) {
+	dir := t.TempDir()
+	modulePath := writeModule(t, dir, "module-a")
+	writeFile(t, filepath.Join(modulePath, "openapi", "values.yaml"), `type: object
+properties:
+  ha:
+    type: boolean
+    default: false
+  debug:
+    type: boolean
+    default: false
+`)
+	writeFile(t, filepath.Join(modulePath, "templates", "deployment.yaml"), `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-module-a
+spec:
+  template:
+    spec:
+      containers:
+{{- if .Values.moduleA.debug }}
+        - name: debug
+          image: nginx
+{{- end }}
+{{- if .Values.moduleA.ha }}
+        - name: ha
+          image: nginx
+{{- end }}
+        - name: app
+          image: nginx
+`)
+	writeFile(t, filepath.Join(modulePath, "values_matrix_test.yaml"), `moduleA:
+  debug:
+    __ConstantChoices__: [false, true]
+`)
+	haPath := filepath.Join(dir, "ha.yaml")
+	writeFile(t, haPath, "moduleA:\n  ha: true\n")
+	allPath := filepath.Join(dir, "all.yaml")
+	writeFile(t, allPath, "moduleA:\n  ha: true\n  debug: true\n")
+
+	cfg, err := config.NewDefault([]string{modulePath}, config.LoaderOptions{})
+	require.NoError(t, err)
+	cfg.Linters.Enable = []string{container.ID}
+
+	opts := Options{ValuesFiles: []string{haPath, allPath}, CacheDir: t.TempDir()}
+	// the second run restores findings from the cache
+	for i := range 2 {
+		result, err := Lint(context.Background(), cfg, []string{modulePath}, opts)
+		require.NoError(t, err)
+		require.Equal(t, i == 1, result.Modules[0].Cached)
+
+		// the linter reports the first container, so every scenario produces findings of its own container
+		scenarios := make(map[string][]string)
+		for _, e := range result.Findings.GetErrors() {
+			container := e.ObjectID[strings.LastIndex(e.ObjectID, " ")+1:]
+			if _, ok := scenarios[container]; ok {
+				require.Equal(t, scenarios[container], e.Scenarios)
+			}
+			scenarios[container] = e.Scenarios
+		}
+		require.Equal(t, map[string][]string{
+			"app": nil,
+			"ha":  {haPath},
+			// the values file and the combination of the values matrix produce the same findings
+			"debug": {allPath, "values_matrix_test.yaml#2"},
+		}, scenarios)
+	}
+}
+
+// objectsLinter reports every rendered object of the module, its "panics" rule panics.
+type objectsLinter struct{}
+
+func (*objectsLinter) Run(ctx context.Context, m linters.Module) (errors.LintRuleErrorsList, error) {
+	if linters.IsRuleEnabled(ctx, "panics") {
+		panic("the rule is run")
+	}
+
+	result := errors.LintRuleErrorsList{}
+	for _, object := range m.GetStorage() {
+		result.Add(errors.NewLintRuleError("objects", object.Identity(), m.GetName(), nil, "%s", object.Unstructured.GetName()).
+			WithRule("objects").WithLocation(object.Location()))
+	}
+
+	return result, nil
+}
+
+func (*objectsLinter) Name() string { return "objects" }
+func (*objectsLinter) Desc() string { return "Reports all objects" }
+func (*objectsLinter) Rules() []linters.Rule {
+	return []linters.Rule{
+		{ID: "objects", Description: "Reports all objects", Severity: errors.SeverityInfo},
+		{ID: "panics", Description: "Panics"},
+	}
+}
+
+func TestRegisterLinter(t *testing.T) {
+	RegisterLinter("objects", func(*config.Config) linters.Linter { return &objectsLinter{} })
+	require.Panics(t, func() {
+		RegisterLinter("objects", func(*config.Config) linters.Linter { return &objectsLinter{} })
+	})
+
+	dir := t.TempDir()
+	modulePath := writeModule(t, dir, "module-a")
+	writeFile(t, filepath.Join(dir, ".dmtlint.yaml"),
+		"linters:\n  enable: [objects]\n  disable: [objects/panics]\n")
+
+	cfg, err := config.NewDefault([]string{modulePath}, config.LoaderOptions{})
+	require.NoError(t, err)
+
+	result, err := Lint(context.Background(), cfg, []string{modulePath}, Options{})
+	require.NoError(t, err)
+
+	// the registered linter is selected by its name, it skips the disabled rule and its rules have their default severities
+	require.Equal(t, 1, result.Findings.Len())
+	e := result.Findings.GetErrors()[0]
+	require.Equal(t, "objects", e.Linter)
+	require.Equal(t, "app", e.Text)
+	require.Equal(t, errors.SeverityInfo, e.Severity)
+
+	// the panic of the enabled rule is reported, though dmt is not listed in enabled linters
+	writeFile(t, filepath.Join(dir, ".dmtlint.yaml"), "linters:\n  enable: [objects]\n")
+	cfg, err = config.NewDefault([]string{modulePath}, config.LoaderOptions{})
+	require.NoError(t, err)
+
+	result, err = Lint(context.Background(), cfg, []string{modulePath}, Options{})
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Findings.Len())
+	e = result.Findings.GetErrors()[0]
+	require.Equal(t, "dmt", e.Linter)
+	require.Equal(t, "internal-error", e.Rule)
+	require.Equal(t, "Linter panicked: the rule is run", e.Text)
+	require.True(t, result.Findings.HasSeverity(errors.SeverityError))
+}
@@ -2,25 +2,59 @@ package errors
 
 import (
 	"cmp"
-	"errors"
 	"fmt"
 	"slices"
 	"strings"
-
-	"github.com/fatih/color"
-	"github.com/kyokomi/emoji"
 )
 
+// Location is a position in a module file. Line and Column start from 1, they are 0 if the position is unknown.
+// Locations of rendered objects are the documents of their templates, they do not point at keys of the objects,
+// see storage.StoreObject.Location.
+type Location struct {
+	// File is the path of the file, relative to the module root.
+	File   string
+	Line   int
+	Column int
+	// EndLine is the last line of the range the error relates to, e.g. the document of a rendered object.
+	// It is 0 if the error relates to the single line.
+	EndLine int
+}
+
+func (l Location) String() string {
+	switch {
+	case l.File == "":
+		return ""
+	case l.Line == 0:
+		return l.File
+	case l.Column == 0:
+		return fmt.Sprintf("%s:%d", l.File, l.Line)
+	default:
+		return fmt.Sprintf("%s:%d:%d", l.File, l.Line, l.Column)
+	}
+}
+
 type LintRuleError struct {
 	Text     string
 	ID       string
 	ObjectID string
 	Value    any
 	Module   string
+	// Linter is the name of the linter that produced the error, it is filled by the manager.
+	Linter string
+	// Rule is the ID of the linter rule that produced the error.
+	Rule string
+	// Severity is the level of the error, it is filled by the manager from the rule defaults
+	// and the config overrides if the rule didn't set it.
+	Severity Severity
+	// Location is the place in the module the error relates to.
+	Location Location
+	// Scenarios are names of values scenarios the error is found with, it is empty if the error is found
+	// with values generated from OpenAPI schemas of the module.
+	Scenarios []string
 }
 
 func (l *LintRuleError) EqualsTo(candidate *LintRuleError) bool {
-	return l.ID == candidate.ID && l.Text == candidate.Text && l.ObjectID == candidate.ObjectID
+	return l.ID == candidate.ID && l.Rule == candidate.Rule && l.Text == candidate.Text && l.ObjectID == candidate.ObjectID
 }
 
 func NewLintRuleError(id, objectID, module string, value any, template string, a ...any) *LintRuleError {
@@ -33,13 +67,64 @@ func NewLintRuleError(id, objectID, module string, value any, template string, a
 	}
 }
 
+// WithFilePath sets the path of the file related to the error, the position in the file is kept.
+func (l *LintRuleError) WithFilePath(path string) *LintRuleError {
+	if l == nil {
+		return nil
+	}
+	l.Location.File = path
+
+	return l
+}
+
+// WithPosition sets the line and the column of the error in the file.
+func (l *LintRuleError) WithPosition(line, column int) *LintRuleError {
+	if l == nil {
+		return nil
+	}
+	l.Location.Line = line
+	l.Location.Column = column
+
+	return l
+}
+
+// WithRule sets the ID of the linter rule that produced the error.
+func (l *LintRuleError) WithRule(rule string) *LintRuleError {
+	if l == nil {
+		return nil
+	}
+	l.Rule = rule
+
+	return l
+}
+
+// WithSeverity sets the severity of the error.
+func (l *LintRuleError) WithSeverity(severity Severity) *LintRuleError {
+	if l == nil {
+		return nil
+	}
+	l.Severity = severity
+
+	return l
+}
+
+// WithLocation sets the location of the error.
+func (l *LintRuleError) WithLocation(location Location) *LintRuleError {
+	if l == nil {
+		return nil
+	}
+	l.Location = location
+
+	return l
+}
+
 type LintRuleErrorsList struct {
 	data []*LintRuleError
 }
 
 // Add adds new error to the list if it doesn't exist yet.
 // It first checks if error is empty (i.e. all its fields are empty strings)
-// and then checks if error with the same ID, ObjectId and Text already exists in the list.
+// and then checks if error with the same ID, Rule, ObjectId and Text already exists in the list.
 func (l *LintRuleErrorsList) Add(e *LintRuleError) {
 	if e == nil {
 		return
@@ -57,48 +142,49 @@ func (l *LintRuleErrorsList) Merge(e LintRuleErrorsList) {
 	}
 }
 
-// ConvertToError converts LintRuleErrorsList to a single error.
-// It returns an error that contains all errors from the list with a nice formatting.
-// If the list is empty, it returns nil.
-func (l *LintRuleErrorsList) ConvertToError() error {
-	if len(l.data) == 0 {
-		return nil
+// SetRule sets the rule for all errors in the list that don't have it yet.
+func (l *LintRuleErrorsList) SetRule(rule string) {
+	for _, el := range l.data {
+		if el.Rule == "" {
+			el.Rule = rule
+		}
+	}
+}
+
+// SetLocation sets the location for all errors in the list that don't have it yet.
+func (l *LintRuleErrorsList) SetLocation(location Location) {
+	for _, el := range l.data {
+		if el.Location.File == "" {
+			el.Location = location
+		}
 	}
+}
+
+// Len returns the number of errors in the list.
+func (l *LintRuleErrorsList) Len() int {
+	return len(l.data)
+}
+
+// GetErrors returns errors from the list sorted by module and object.
+func (l *LintRuleErrorsList) GetErrors() []*LintRuleError {
 	slices.SortFunc(l.data, func(a, b *LintRuleError) int {
 		return cmp.Or(
 			cmp.Compare(a.Module, b.Module),
 			cmp.Compare(a.ObjectID, b.ObjectID),
 		)
 	})
-	builder := strings.Builder{}
-	for _, err := range l.data {
-		builder.WriteString(fmt.Sprintf(
-			"%s%s\n\tMessage\t- %s\n\tObject\t- %s\n\tModule\t- %s\n",
-			emoji.Sprintf(":monkey:"),
-			color.New(color.FgHiBlue).SprintfFunc()("[#%s]", err.ID),
-			color.New(color.FgRed).SprintfFunc()(err.Text),
-			err.ObjectID,
-			err.Module,
-		))
-
-		if err.Value != nil {
-			value := fmt.Sprintf("%v", err.Value)
-			builder.WriteString(fmt.Sprintf("\tValue\t- %s\n", value))
-		}
-		builder.WriteString("\n")
-	}
 
-	return errors.New(builder.String())
+	return l.data
 }
 
-var WarningsOnly []string
-
-func (l *LintRuleErrorsList) Critical() bool {
-	for _, err := range l.data {
-		if !slices.Contains(WarningsOnly, err.ID) {
-			return true
-		}
-	}
+// Critical returns true if the error has the error severity.
+func (l *LintRuleError) Critical() bool {
+	return l.Severity.AtLeast(SeverityError)
+}
 
-	return false
+// HasSeverity returns true if the list contains errors with the same or higher severity.
+func (l *LintRuleErrorsList) HasSeverity(severity Severity) bool {
+	return slices.ContainsFunc(l.data, func(e *LintRuleError) bool {
+		return e.Severity.AtLeast(severity)
+	})
 }
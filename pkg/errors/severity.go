@@ -0,0 +1,58 @@
+package errors
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity is the level of an error.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+	SeverityInfo    Severity = "info"
+)
+
+var severityRanks = map[Severity]int{
+	SeverityInfo:    1,
+	SeverityWarning: 2,
+	SeverityError:   3,
+}
+
+// Severities returns all severities from the lowest to the highest.
+func Severities() []Severity {
+	return []Severity{SeverityInfo, SeverityWarning, SeverityError}
+}
+
+// ParseSeverity returns the severity by its name.
+func ParseSeverity(s string) (Severity, error) {
+	severity := Severity(strings.ToLower(s))
+	if _, ok := severityRanks[severity]; !ok {
+		return "", fmt.Errorf("unknown severity %q, available severities: error, warning, info", s)
+	}
+
+	return severity, nil
+}
+
+// AtLeast reports whether the severity is the same or higher than the other one.
+// Empty severity is the same as SeverityError.
+func (s Severity) AtLeast(other Severity) bool {
+	return s.rank() >= other.rank()
+}
+
+func (s Severity) rank() int {
+	if rank, ok := severityRanks[s]; ok {
+		return rank
+	}
+
+	return severityRanks[SeverityError]
+}
+
+func (s Severity) String() string {
+	if s == "" {
+		return string(SeverityError)
+	}
+
+	return string(s)
+}
@@ -0,0 +1,72 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+const (
+	Text  = "text"
+	JSON  = "json"
+	SARIF = "sarif"
+	JUnit = "junit"
+)
+
+// Report contains lint results and the context they were produced in.
+type Report struct {
+	// Version is the version of dmt that produced the report.
+	Version string
+	Errors  *errors.LintRuleErrorsList
+	Linters []LinterInfo
+	Modules []ModuleInfo
+}
+
+type LinterInfo struct {
+	Name  string
+	Desc  string
+	Rules []linters.Rule
+}
+
+type ModuleInfo struct {
+	Name string
+	// Path is the absolute path of the module root.
+	Path string
+}
+
+// Formatter writes lint results to the output in a specific format.
+type Formatter interface {
+	Format(w io.Writer, report *Report) error
+}
+
+var formatters = map[string]func() Formatter{
+	Text:  func() Formatter { return &TextFormatter{} },
+	JSON:  func() Formatter { return &JSONFormatter{} },
+	SARIF: func() Formatter { return &SARIFFormatter{} },
+	JUnit: func() Formatter { return &JUnitFormatter{} },
+}
+
+// Names returns names of all available formatters.
+func Names() []string {
+	names := make([]string, 0, len(formatters))
+	for name := range formatters {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	return names
+}
+
+// New returns formatter by its name.
+func New(name string) (Formatter, error) {
+	f, ok := formatters[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown output format %q, available formats: %s", name, strings.Join(Names(), ", "))
+	}
+
+	return f(), nil
+}
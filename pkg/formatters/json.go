@@ -0,0 +1,124 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// JSONVersion is the version of the JSON document layout, it is increased on breaking changes.
+const JSONVersion = 1
+
+// JSONFormatter prints lint results as a single JSON document.
+type JSONFormatter struct{}
+
+type jsonReport struct {
+	Version  int           `json:"version"`
+	Findings []jsonFinding `json:"findings"`
+	Summary  jsonSummary   `json:"summary"`
+}
+
+type jsonFinding struct {
+	Linter   string `json:"linter"`
+	ID       string `json:"id"`
+	Rule     string `json:"rule,omitempty"`
+	ObjectID string `json:"objectId"`
+	Module   string `json:"module"`
+	FilePath string `json:"filePath,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+	Text     string `json:"text"`
+	Value    any    `json:"value,omitempty"`
+	Severity string `json:"severity"`
+	Critical bool   `json:"critical"`
+	// Scenarios are names of values scenarios the finding is found with, see errors.LintRuleError.
+	Scenarios []string `json:"scenarios,omitempty"`
+}
+
+type jsonSummary struct {
+	Total      int            `json:"total"`
+	Critical   int            `json:"critical"`
+	Severities map[string]int `json:"severities"`
+	Linters    map[string]int `json:"linters"`
+	Modules    map[string]int `json:"modules"`
+}
+
+func (*JSONFormatter) Format(w io.Writer, r *Report) error {
+	report := jsonReport{
+		Version:  JSONVersion,
+		Findings: make([]jsonFinding, 0, r.Errors.Len()),
+		Summary: jsonSummary{
+			Severities: make(map[string]int),
+			Linters:    make(map[string]int),
+			Modules:    make(map[string]int),
+		},
+	}
+
+	for _, err := range r.Errors.GetErrors() {
+		finding := jsonFinding{
+			Linter:    err.Linter,
+			ID:        err.ID,
+			Rule:      err.Rule,
+			ObjectID:  err.ObjectID,
+			Module:    err.Module,
+			FilePath:  err.Location.File,
+			Line:      err.Location.Line,
+			Column:    err.Location.Column,
+			Text:      err.Text,
+			Value:     JSONValue(err.Value),
+			Severity:  err.Severity.String(),
+			Critical:  err.Critical(),
+			Scenarios: err.Scenarios,
+		}
+		report.Findings = append(report.Findings, finding)
+
+		report.Summary.Total++
+		if finding.Critical {
+			report.Summary.Critical++
+		}
+		report.Summary.Severities[finding.Severity]++
+		report.Summary.Linters[finding.Linter]++
+		report.Summary.Modules[finding.Module]++
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(report)
+}
+
+// JSONValuer is implemented by values of errors which are converted to JSON on their own,
+// e.g. values restored from the cache keep the JSON of the original values.
+type JSONValuer interface {
+	JSONValue() any
+}
+
+// JSONValue converts the value of the error to something that can be safely marshaled to JSON.
+func JSONValue(value any) any {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case JSONValuer:
+		return v.JSONValue()
+	case error:
+		return v.Error()
+	case fmt.Stringer:
+		return v.String()
+	}
+
+	rv := reflect.ValueOf(value)
+	switch rv.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+	default:
+	}
+
+	if _, err := json.Marshal(value); err != nil {
+		return fmt.Sprintf("%v", value)
+	}
+
+	return value
+}
@@ -0,0 +1,56 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestJSONFormatter_Format(t *testing.T) {
+	list := errors.LintRuleErrorsList{}
+	e := errors.NewLintRuleError("container", "kind = Deployment ; name = a", "module-a", nil, "Container SecurityContext is not defined")
+	e.Linter = "container"
+	list.Add(e)
+	e = errors.NewLintRuleError("probes", "kind = Deployment ; name = b", "module-b", fmt.Errorf("broken"), "Container does not use correct probes").
+		WithSeverity(errors.SeverityWarning)
+	e.Linter = "probes"
+	e.Scenarios = []string{"ha.yaml"}
+	list.Add(e)
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, (&JSONFormatter{}).Format(buf, &Report{Errors: &list}))
+
+	var report jsonReport
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &report))
+
+	require.Equal(t, JSONVersion, report.Version)
+	require.Len(t, report.Findings, 2)
+	require.Equal(t, "module-a", report.Findings[0].Module)
+	require.True(t, report.Findings[0].Critical)
+	require.Equal(t, "error", report.Findings[0].Severity)
+	require.Nil(t, report.Findings[0].Value)
+	require.False(t, report.Findings[1].Critical)
+	require.Equal(t, "warning", report.Findings[1].Severity)
+	require.Equal(t, "broken", report.Findings[1].Value)
+	require.Nil(t, report.Findings[0].Scenarios)
+	require.Equal(t, []string{"ha.yaml"}, report.Findings[1].Scenarios)
+	require.Equal(t, 2, report.Summary.Total)
+	require.Equal(t, 1, report.Summary.Critical)
+	require.Equal(t, map[string]int{"error": 1, "warning": 1}, report.Summary.Severities)
+	require.Equal(t, map[string]int{"container": 1, "probes": 1}, report.Summary.Linters)
+	require.Equal(t, map[string]int{"module-a": 1, "module-b": 1}, report.Summary.Modules)
+}
+
+func TestNew(t *testing.T) {
+	f, err := New("JSON")
+	require.NoError(t, err)
+	require.IsType(t, &JSONFormatter{}, f)
+
+	_, err = New("yaml")
+	require.Error(t, err)
+}
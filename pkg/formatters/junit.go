@@ -0,0 +1,158 @@
+package formatters
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// JUnitFormatter prints lint results as a JUnit XML report:
+// every module is a test suite and every linter is a test case in it.
+type JUnitFormatter struct{}
+
+type junitTestSuites struct {
+	XMLName    xml.Name         `xml:"testsuites"`
+	Name       string           `xml:"name,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	TestSuites []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string          `xml:"name,attr"`
+	Tests      int             `xml:"tests,attr"`
+	Failures   int             `xml:"failures,attr"`
+	Errors     int             `xml:"errors,attr"`
+	Skipped    int             `xml:"skipped,attr"`
+	Properties []junitProperty `xml:"properties>property,omitempty"`
+	TestCases  []junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+type junitFindings struct {
+	critical []*errors.LintRuleError
+	warnings []*errors.LintRuleError
+}
+
+func (*JUnitFormatter) Format(w io.Writer, report *Report) error {
+	modules := report.Modules
+	linters := make([]string, 0, len(report.Linters))
+	for _, linter := range report.Linters {
+		linters = append(linters, linter.Name)
+	}
+
+	// findings[module][linter]
+	findings := make(map[string]map[string]*junitFindings)
+	for _, err := range report.Errors.GetErrors() {
+		if _, ok := findings[err.Module]; !ok {
+			findings[err.Module] = make(map[string]*junitFindings)
+			if !slices.ContainsFunc(modules, func(m ModuleInfo) bool { return m.Name == err.Module }) {
+				modules = append(modules, ModuleInfo{Name: err.Module})
+			}
+		}
+
+		f, ok := findings[err.Module][err.Linter]
+		if !ok {
+			f = &junitFindings{}
+			findings[err.Module][err.Linter] = f
+		}
+
+		if err.Critical() {
+			f.critical = append(f.critical, err)
+		} else {
+			f.warnings = append(f.warnings, err)
+		}
+	}
+
+	suites := junitTestSuites{Name: "dmt"}
+	for _, m := range modules {
+		suite := junitTestSuite{Name: m.Name}
+		if m.Path != "" {
+			suite.Properties = []junitProperty{{Name: "path", Value: m.Path}}
+		}
+
+		moduleLinters := slices.Clone(linters)
+		for _, linter := range slices.Sorted(maps.Keys(findings[m.Name])) {
+			if !slices.Contains(moduleLinters, linter) {
+				moduleLinters = append(moduleLinters, linter)
+			}
+		}
+
+		for _, linter := range moduleLinters {
+			testCase := junitTestCase{Name: linter, ClassName: m.Name}
+
+			if f, ok := findings[m.Name][linter]; ok {
+				if len(f.critical) > 0 {
+					testCase.Failure = &junitFailure{
+						Message: fmt.Sprintf("%d finding(s)", len(f.critical)),
+						Type:    "error",
+						Text:    junitText(f.critical),
+					}
+					suite.Failures++
+				}
+				testCase.SystemOut = junitText(f.warnings)
+			}
+
+			suite.TestCases = append(suite.TestCases, testCase)
+			suite.Tests++
+		}
+
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.TestSuites = append(suites.TestSuites, suite)
+	}
+
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(suites); err != nil {
+		return err
+	}
+
+	_, err := io.WriteString(w, "\n")
+
+	return err
+}
+
+func junitText(list []*errors.LintRuleError) string {
+	builder := strings.Builder{}
+	for _, err := range list {
+		builder.WriteString(fmt.Sprintf("%s: [#%s] %s\n\tObject - %s\n", err.Severity, err.ID, err.Text, err.ObjectID))
+		if err.Location.File != "" {
+			builder.WriteString(fmt.Sprintf("\tFile - %s\n", err.Location))
+		}
+		if err.Value != nil {
+			builder.WriteString(fmt.Sprintf("\tValue - %v\n", err.Value))
+		}
+		if len(err.Scenarios) > 0 {
+			builder.WriteString(fmt.Sprintf("\tScenarios - %s\n", strings.Join(err.Scenarios, ", ")))
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,53 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+func TestJUnitFormatter_Format(t *testing.T) {
+	list := errors.LintRuleErrorsList{}
+	for _, id := range []string{"vpa", "pdb"} {
+		e := errors.NewLintRuleError(id, "kind = Deployment ; name = app", "module-a", nil, "message %s", id)
+		e.Linter = "k8s-resources"
+		if id == "pdb" {
+			e.WithSeverity(errors.SeverityWarning)
+		}
+		list.Add(e)
+	}
+
+	report := &Report{
+		Version: "v1.0.0",
+		Errors:  &list,
+		Linters: []LinterInfo{{Name: "helm"}, {Name: "k8s-resources"}},
+		Modules: []ModuleInfo{{Name: "module-a", Path: "/modules/module-a"}, {Name: "module-b", Path: "/modules/module-b"}},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, (&JUnitFormatter{}).Format(buf, report))
+
+	var suites junitTestSuites
+	require.NoError(t, xml.Unmarshal(buf.Bytes(), &suites))
+	require.Equal(t, 4, suites.Tests)
+	require.Equal(t, 1, suites.Failures)
+	require.Len(t, suites.TestSuites, 2)
+
+	suite := suites.TestSuites[0]
+	require.Equal(t, "module-a", suite.Name)
+	require.Len(t, suite.TestCases, 2)
+	require.Nil(t, suite.TestCases[0].Failure)
+
+	testCase := suite.TestCases[1]
+	require.Equal(t, "k8s-resources", testCase.Name)
+	require.NotNil(t, testCase.Failure)
+	require.Contains(t, testCase.Failure.Text, "message vpa")
+	require.NotContains(t, testCase.Failure.Text, "message pdb")
+	require.Contains(t, testCase.SystemOut, "warning: [#pdb] message pdb")
+
+	require.Equal(t, 0, suites.TestSuites[1].Failures)
+}
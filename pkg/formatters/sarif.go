@@ -0,0 +1,265 @@
+package formatters
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+const (
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifVersion = "2.1.0"
+	sarifToolURI = "https://github.com/deckhouse/dmt"
+)
+
+// SARIFFormatter prints lint results as a SARIF 2.1.0 log.
+type SARIFFormatter struct{}
+
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool               sarifTool                        `json:"tool"`
+	OriginalURIBaseIDs map[string]sarifArtifactLocation `json:"originalUriBaseIds,omitempty"`
+	Results            []sarifResult                    `json:"results"`
+}
+
+type sarifTool struct {
+	Driver     sarifToolComponent   `json:"driver"`
+	Extensions []sarifToolComponent `json:"extensions,omitempty"`
+}
+
+type sarifToolComponent struct {
+	Name             string               `json:"name"`
+	Version          string               `json:"version,omitempty"`
+	InformationURI   string               `json:"informationUri,omitempty"`
+	ShortDescription *sarifMessage        `json:"shortDescription,omitempty"`
+	Rules            []sarifReportingRule `json:"rules,omitempty"`
+}
+
+type sarifReportingRule struct {
+	ID               string        `json:"id"`
+	ShortDescription *sarifMessage `json:"shortDescription,omitempty"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Rule      sarifRuleRef    `json:"rule"`
+	Level     string          `json:"level"`
+	Message   sarifMessage    `json:"message"`
+	Locations []sarifLocation `json:"locations,omitempty"`
+}
+
+type sarifRuleRef struct {
+	ID            string            `json:"id"`
+	Index         int               `json:"index"`
+	ToolComponent sarifComponentRef `json:"toolComponent"`
+}
+
+type sarifComponentRef struct {
+	Name  string `json:"name"`
+	Index int    `json:"index"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI       string `json:"uri"`
+	URIBaseID string `json:"uriBaseId,omitempty"`
+}
+
+func (*SARIFFormatter) Format(w io.Writer, report *Report) error {
+	run := sarifRun{
+		Tool: sarifTool{
+			Driver: sarifToolComponent{
+				Name:           "dmt",
+				Version:        report.Version,
+				InformationURI: sarifToolURI,
+			},
+		},
+		OriginalURIBaseIDs: make(map[string]sarifArtifactLocation),
+		Results:            make([]sarifResult, 0, report.Errors.Len()),
+	}
+
+	for _, m := range report.Modules {
+		run.OriginalURIBaseIDs[m.Name] = sarifArtifactLocation{URI: directoryURI(m.Path)}
+	}
+
+	components := newSARIFComponents(report.Linters)
+
+	for _, err := range report.Errors.GetErrors() {
+		ruleID := sarifRuleID(err)
+		componentIndex, ruleIndex := components.rule(err.Linter, ruleID)
+
+		result := sarifResult{
+			RuleID: ruleID,
+			Rule: sarifRuleRef{
+				ID:    ruleID,
+				Index: ruleIndex,
+				ToolComponent: sarifComponentRef{
+					Name:  err.Linter,
+					Index: componentIndex,
+				},
+			},
+			Level:   sarifLevel(err),
+			Message: sarifMessage{Text: sarifText(err)},
+		}
+
+		if err.Location.File != "" {
+			artifact := sarifArtifactLocation{URI: filepath.ToSlash(err.Location.File), URIBaseID: err.Module}
+			if err.Module == "" {
+				// findings without a module are in files outside modules, e.g. expired exclusions in config files
+				artifact = sarifArtifactLocation{URI: fileURI(err.Location.File)}
+			}
+
+			location := sarifLocation{
+				PhysicalLocation: sarifPhysicalLocation{ArtifactLocation: artifact},
+			}
+			if err.Location.Line > 0 {
+				location.PhysicalLocation.Region = &sarifRegion{
+					StartLine:   err.Location.Line,
+					StartColumn: err.Location.Column,
+				}
+			}
+			result.Locations = []sarifLocation{location}
+		}
+
+		run.Results = append(run.Results, result)
+	}
+
+	run.Tool.Extensions = components.list
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(sarifLog{
+		Schema:  sarifSchema,
+		Version: sarifVersion,
+		Runs:    []sarifRun{run},
+	})
+}
+
+// sarifComponents builds a tool component for each linter with all its rules,
+// linters and rules which are not described (e.g. findings of dmt itself) are added when they are reported.
+type sarifComponents struct {
+	list []sarifToolComponent
+}
+
+func newSARIFComponents(linterInfos []LinterInfo) *sarifComponents {
+	c := &sarifComponents{}
+	for _, linter := range linterInfos {
+		component := sarifToolComponent{
+			Name:             linter.Name,
+			ShortDescription: &sarifMessage{Text: linter.Desc},
+		}
+		for _, rule := range linter.Rules {
+			component.Rules = append(component.Rules, sarifReportingRule{
+				ID:               rule.ID,
+				ShortDescription: &sarifMessage{Text: rule.Description},
+			})
+		}
+		c.list = append(c.list, component)
+	}
+
+	return c
+}
+
+// rule returns indexes of the linter component and the rule inside it, adding them if needed.
+func (c *sarifComponents) rule(linter, id string) (componentIndex, ruleIndex int) {
+	componentIndex = slices.IndexFunc(c.list, func(tc sarifToolComponent) bool {
+		return tc.Name == linter
+	})
+	if componentIndex < 0 {
+		c.list = append(c.list, sarifToolComponent{Name: linter})
+		componentIndex = len(c.list) - 1
+	}
+
+	component := &c.list[componentIndex]
+	ruleIndex = slices.IndexFunc(component.Rules, func(r sarifReportingRule) bool {
+		return r.ID == id
+	})
+	if ruleIndex < 0 {
+		component.Rules = append(component.Rules, sarifReportingRule{ID: id})
+		ruleIndex = len(component.Rules) - 1
+	}
+
+	return componentIndex, ruleIndex
+}
+
+// sarifRuleID returns the rule ID of the error, the error ID is used for errors without a rule.
+func sarifRuleID(err *errors.LintRuleError) string {
+	if err.Rule != "" {
+		return err.Rule
+	}
+
+	return err.ID
+}
+
+func sarifLevel(err *errors.LintRuleError) string {
+	switch err.Severity {
+	case errors.SeverityWarning:
+		return "warning"
+	case errors.SeverityInfo:
+		return "note"
+	default:
+		return "error"
+	}
+}
+
+func sarifText(err *errors.LintRuleError) string {
+	parts := []string{err.Text}
+	if err.ObjectID != "" {
+		parts = append(parts, "Object: "+err.ObjectID)
+	}
+	if err.Value != nil {
+		parts = append(parts, fmt.Sprintf("Value: %v", err.Value))
+	}
+	if len(err.Scenarios) > 0 {
+		parts = append(parts, "Scenarios: "+strings.Join(err.Scenarios, ", "))
+	}
+
+	return strings.Join(parts, "\n")
+}
+
+func directoryURI(path string) string {
+	u := url.URL{Scheme: "file", Path: filepath.ToSlash(path)}
+	if !strings.HasSuffix(u.Path, "/") {
+		u.Path += "/"
+	}
+
+	return u.String()
+}
+
+// fileURI returns the absolute "file" URI of the path, relative paths are relative to the working directory.
+func fileURI(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		path = abs
+	}
+
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,81 @@
+package formatters
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+func TestSARIFFormatter_Format(t *testing.T) {
+	list := errors.LintRuleErrorsList{}
+	for _, id := range []string{"vpa", "pdb", "vpa"} {
+		e := errors.NewLintRuleError(id, "kind = Deployment ; name = "+id, "module-a", nil, "message").
+			WithFilePath("templates/app.yaml").WithPosition(3, 1)
+		e.Linter = "k8s-resources"
+		list.Add(e)
+	}
+	e := errors.NewLintRuleError("helm", "module-a", "module-a", nil, "no .helmignore")
+	e.Linter = "helm"
+	list.Add(e)
+	e = errors.NewLintRuleError("expired-exclusion", "license.copyright-excludes", "", nil, "expired").
+		WithRule("expired-exclusion").WithFilePath("/repo/.dmtlint.yaml")
+	e.Linter = "dmt"
+	list.Add(e)
+
+	report := &Report{
+		Version: "v1.0.0",
+		Errors:  &list,
+		Linters: []LinterInfo{
+			{Name: "helm", Desc: "Lint helm objects"},
+			{Name: "k8s-resources", Desc: "Lint k8s-resources", Rules: []linters.Rule{
+				{ID: "vpa", Description: "Pod controllers must have a VPA"},
+				{ID: "crds", Description: "CRDs must be valid"},
+			}},
+		},
+		Modules: []ModuleInfo{{Name: "module-a", Path: "/modules/module-a"}},
+	}
+
+	buf := &bytes.Buffer{}
+	require.NoError(t, (&SARIFFormatter{}).Format(buf, report))
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &log))
+	require.Len(t, log.Runs, 1)
+
+	run := log.Runs[0]
+	require.Equal(t, "file:///modules/module-a/", run.OriginalURIBaseIDs["module-a"].URI)
+	require.Len(t, run.Tool.Extensions, 3)
+	require.Equal(t, "dmt", run.Tool.Extensions[2].Name)
+
+	// all rules of linters are listed, reported rules without a description are added
+	k8sResources := run.Tool.Extensions[1]
+	require.Equal(t, "k8s-resources", k8sResources.Name)
+	require.Equal(t, []sarifReportingRule{
+		{ID: "vpa", ShortDescription: &sarifMessage{Text: "Pod controllers must have a VPA"}},
+		{ID: "crds", ShortDescription: &sarifMessage{Text: "CRDs must be valid"}},
+		{ID: "pdb"},
+	}, k8sResources.Rules)
+	require.Len(t, run.Results, 4)
+
+	for _, result := range run.Results {
+		component := run.Tool.Extensions[result.Rule.ToolComponent.Index]
+		require.Equal(t, result.RuleID, component.Rules[result.Rule.Index].ID)
+		switch result.RuleID {
+		case "helm":
+			require.Empty(t, result.Locations)
+			continue
+		case "expired-exclusion":
+			// the config file is not in a module, so its URI is absolute
+			require.Equal(t, sarifArtifactLocation{URI: "file:///repo/.dmtlint.yaml"}, result.Locations[0].PhysicalLocation.ArtifactLocation)
+			continue
+		}
+		require.Equal(t, "templates/app.yaml", result.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+		require.Equal(t, "module-a", result.Locations[0].PhysicalLocation.ArtifactLocation.URIBaseID)
+		require.Equal(t, &sarifRegion{StartLine: 3, StartColumn: 1}, result.Locations[0].PhysicalLocation.Region)
+	}
+}
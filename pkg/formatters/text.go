@@ -0,0 +1,67 @@
+package formatters
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/kyokomi/emoji"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// TextFormatter prints lint results in a human-readable colored form.
+type TextFormatter struct{}
+
+func (*TextFormatter) Format(w io.Writer, report *Report) error {
+	if report.Errors.Len() == 0 {
+		return nil
+	}
+
+	builder := strings.Builder{}
+	for _, err := range report.Errors.GetErrors() {
+		builder.WriteString(fmt.Sprintf(
+			"%s%s\n\tMessage\t- %s\n\tSeverity\t- %s\n\tObject\t- %s\n\tModule\t- %s\n",
+			emoji.Sprintf(":monkey:"),
+			color.New(color.FgHiBlue).SprintfFunc()("[#%s]", err.ID),
+			severityColor(err.Severity).SprintfFunc()(err.Text),
+			err.Severity,
+			err.ObjectID,
+			err.Module,
+		))
+
+		if err.Rule != "" {
+			builder.WriteString(fmt.Sprintf("\tRule\t- %s/%s\n", err.Linter, err.Rule))
+		}
+
+		if err.Location.File != "" {
+			builder.WriteString(fmt.Sprintf("\tFile\t- %s\n", err.Location))
+		}
+
+		if err.Value != nil {
+			value := fmt.Sprintf("%v", err.Value)
+			builder.WriteString(fmt.Sprintf("\tValue\t- %s\n", value))
+		}
+
+		if len(err.Scenarios) > 0 {
+			builder.WriteString(fmt.Sprintf("\tScenarios\t- %s\n", strings.Join(err.Scenarios, ", ")))
+		}
+		builder.WriteString("\n")
+	}
+
+	_, err := fmt.Fprintln(w, builder.String())
+
+	return err
+}
+
+func severityColor(severity errors.Severity) *color.Color {
+	switch severity {
+	case errors.SeverityWarning:
+		return color.New(color.FgYellow)
+	case errors.SeverityInfo:
+		return color.New(color.FgCyan)
+	default:
+		return color.New(color.FgRed)
+	}
+}
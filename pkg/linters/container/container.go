@@ -1,17 +1,18 @@
 package container
 
 import (
+	"context"
+
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 const (
 	ID = "container"
 )
 
-var Cfg *config.ContainerSettings
-
 // Container linter
 type Container struct {
 	name, desc string
@@ -19,8 +20,6 @@ type Container struct {
 }
 
 func New(cfg *config.ContainerSettings) *Container {
-	Cfg = cfg
-
 	return &Container{
 		name: "container",
 		desc: "Lint container objects",
@@ -28,13 +27,13 @@ func New(cfg *config.ContainerSettings) *Container {
 	}
 }
 
-func (*Container) Run(m *module.Module) (result errors.LintRuleErrorsList, err error) {
+func (o *Container) Run(ctx context.Context, m *module.Module) (result errors.LintRuleErrorsList, err error) {
 	if m == nil {
 		return result, err
 	}
 
 	for _, object := range m.GetStorage() {
-		result.Merge(applyContainerRules(object))
+		result.Merge(o.applyContainerRules(ctx, object))
 	}
 
 	return result, nil
@@ -47,3 +46,7 @@ func (o *Container) Name() string {
 func (o *Container) Desc() string {
 	return o.desc
 }
+
+func (*Container) Rules() []linters.Rule {
+	return Rules
+}
@@ -1,19 +1,56 @@
 package container
 
 import (
+	"context"
 	"regexp"
-	"strings"
 
 	"github.com/google/go-containerregistry/pkg/name"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/deckhouse/dmt/internal/storage"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 const defaultRegistry = "registry.example.com/deckhouse"
 
-func applyContainerRules(object storage.StoreObject) (result errors.LintRuleErrorsList) {
+// Rule IDs of the linter.
+const (
+	NameDuplicatesRule   = "name-duplicates"
+	EnvDuplicatesRule    = "env-duplicates"
+	ImageRegistryRule    = "image-registry"
+	ImagePullPolicyRule  = "image-pull-policy"
+	EphemeralStorageRule = "ephemeral-storage"
+	SecurityContextRule  = "security-context"
+	PortsRule            = "ports"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: NameDuplicatesRule, Description: "Containers of a pod must have unique names"},
+	{ID: EnvDuplicatesRule, Description: "Env variables of a container must have unique names"},
+	{ID: ImageRegistryRule, Description: "Images must be deployed from the default registry by digest"},
+	{ID: ImagePullPolicyRule, Description: `Containers must use the "IfNotPresent" imagePullPolicy ("Always" for deckhouse)`},
+	{ID: EphemeralStorageRule, Description: "Containers must request ephemeral storage"},
+	{ID: SecurityContextRule, Description: "Containers must have a security context"},
+	{ID: PortsRule, Description: "Containers must not use ports <= 1024"},
+}
+
+// containerRules are checks applied to containers of every pod controller of the module.
+var containerRules = []struct {
+	id    string
+	check func(c *Container, object storage.StoreObject, containers []v1.Container) *errors.LintRuleError
+}{
+	{NameDuplicatesRule, (*Container).containerNameDuplicates},
+	{EnvDuplicatesRule, (*Container).containerEnvVariablesDuplicates},
+	{ImageRegistryRule, (*Container).containerImageDigestCheck},
+	{ImagePullPolicyRule, (*Container).containersImagePullPolicy},
+	{EphemeralStorageRule, (*Container).containerStorageEphemeral},
+	{SecurityContextRule, (*Container).containerSecurityContext},
+	{PortsRule, (*Container).containerPorts},
+}
+
+func (c *Container) applyContainerRules(ctx context.Context, object storage.StoreObject) (result errors.LintRuleErrorsList) {
 	containers, err := object.GetContainers()
 	if err != nil {
 		return
@@ -29,19 +66,19 @@ func applyContainerRules(object storage.StoreObject) (result errors.LintRuleErro
 
 	result = errors.LintRuleErrorsList{}
 
-	result.Add(containerNameDuplicates(object, containers))
-	result.Add(containerEnvVariablesDuplicates(object, containers))
-	result.Add(containerImageDigestCheck(object, containers))
-	result.Add(containersImagePullPolicy(object, containers))
+	for _, rule := range containerRules {
+		if !linters.IsRuleEnabled(ctx, rule.id) {
+			continue
+		}
+		result.Add(rule.check(c, object, containers).WithRule(rule.id))
+	}
 
-	result.Add(containerStorageEphemeral(object, containers))
-	result.Add(containerSecurityContext(object, containers))
-	result.Add(containerPorts(object, containers))
+	result.SetLocation(object.Location())
 
 	return result
 }
 
-func containersImagePullPolicy(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containersImagePullPolicy(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	if len(containers) == 0 {
 		return nil
 	}
@@ -66,31 +103,31 @@ func containersImagePullPolicy(object storage.StoreObject, containers []v1.Conta
 		return nil
 	}
 
-	return containerImagePullPolicyIfNotPresent(object, containers)
+	return c.containerImagePullPolicyIfNotPresent(object, containers)
 }
 
-func containerNameDuplicates(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerNameDuplicates(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	names := make(map[string]struct{})
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
-		}
-		if _, ok := names[containers[i].Name]; ok {
-			return errors.NewLintRuleError(ID, object.Identity(), containers[i].Name, nil, "Duplicate container name")
+		if _, ok := names[containers[i].Name]; ok && !c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
+			return errors.NewLintRuleError(
+				ID,
+				object.Identity()+"; container = "+containers[i].Name,
+				containers[i].Name,
+				nil,
+				"Duplicate container name",
+			)
 		}
 		names[containers[i].Name] = struct{}{}
 	}
 	return nil
 }
 
-func containerEnvVariablesDuplicates(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerEnvVariablesDuplicates(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
-		}
 		envVariables := make(map[string]struct{})
 		for _, variable := range containers[i].Env {
-			if _, ok := envVariables[variable.Name]; ok {
+			if _, ok := envVariables[variable.Name]; ok && !c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
 				return errors.NewLintRuleError(
 					ID,
 					object.Identity()+"; container = "+containers[i].Name,
@@ -105,75 +142,60 @@ func containerEnvVariablesDuplicates(object storage.StoreObject, containers []v1
 	return nil
 }
 
-func shouldSkipModuleContainer(md, container string) bool {
-	for _, line := range Cfg.SkipContainers {
-		els := strings.Split(line, ":")
-		if len(els) != 2 {
-			continue
-		}
-		moduleName := strings.TrimSpace(els[0])
-		containerName := strings.TrimSpace(els[1])
-
-		checkContainer := container == containerName
-		subString := strings.Trim(containerName, "*")
-		if len(subString) != len(containerName) {
-			checkContainer = strings.Contains(container, subString)
-		}
-
-		if md == moduleName && checkContainer {
-			return true
-		}
-	}
-
-	return false
+// shouldSkipModuleContainer reports whether the finding of the container is suppressed by the settings,
+// it is called only when a check found something.
+func (c *Container) shouldSkipModuleContainer(md, container string) bool {
+	return c.cfg.IsContainerSkipped(md, container).Suppress()
 }
 
-func containerImageDigestCheck(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerImageDigestCheck(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
+		if lerr := containerImageDigest(object, containers[i]); lerr != nil && !c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
+			return lerr
 		}
+	}
+	return nil
+}
 
-		re := regexp.MustCompile(`(?P<repository>.+)([@:])imageHash[-a-z0-9A-Z]+$`)
-		match := re.FindStringSubmatch(containers[i].Image)
-		if len(match) == 0 {
-			return errors.NewLintRuleError(ID,
-				object.Identity()+"; container = "+containers[i].Name,
-				object.Unstructured.GetName(),
-				nil,
-				"Cannot parse repository from image",
-			)
-		}
-		repo, err := name.NewRepository(match[re.SubexpIndex("repository")])
-		if err != nil {
-			return errors.NewLintRuleError(ID,
-				object.Identity()+"; container = "+containers[i].Name,
-				object.Unstructured.GetName(),
-				nil,
-				"Cannot parse repository from image: %s", containers[i].Image,
-			)
-		}
+func containerImageDigest(object storage.StoreObject, container v1.Container) *errors.LintRuleError {
+	re := regexp.MustCompile(`(?P<repository>.+)([@:])imageHash[-a-z0-9A-Z]+$`)
+	match := re.FindStringSubmatch(container.Image)
+	if len(match) == 0 {
+		return errors.NewLintRuleError(ID,
+			object.Identity()+"; container = "+container.Name,
+			object.Unstructured.GetName(),
+			nil,
+			"Cannot parse repository from image",
+		)
+	}
+	repo, err := name.NewRepository(match[re.SubexpIndex("repository")])
+	if err != nil {
+		return errors.NewLintRuleError(ID,
+			object.Identity()+"; container = "+container.Name,
+			object.Unstructured.GetName(),
+			nil,
+			"Cannot parse repository from image: %s", container.Image,
+		)
+	}
 
-		if repo.Name() != defaultRegistry {
-			return errors.NewLintRuleError(ID,
-				object.Identity()+"; container = "+containers[i].Name,
-				object.Unstructured.GetName(),
-				nil,
-				"All images must be deployed from the same default registry: %s current: %s",
-				defaultRegistry,
-				repo.RepositoryStr(),
-			)
-		}
+	if repo.Name() != defaultRegistry {
+		return errors.NewLintRuleError(ID,
+			object.Identity()+"; container = "+container.Name,
+			object.Unstructured.GetName(),
+			nil,
+			"All images must be deployed from the same default registry: %s current: %s",
+			defaultRegistry,
+			repo.RepositoryStr(),
+		)
 	}
+
 	return nil
 }
 
-func containerImagePullPolicyIfNotPresent(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerImagePullPolicyIfNotPresent(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
-		}
-		if containers[i].ImagePullPolicy == "" || containers[i].ImagePullPolicy == "IfNotPresent" {
+		if containers[i].ImagePullPolicy == "" || containers[i].ImagePullPolicy == "IfNotPresent" ||
+			c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
 			continue
 		}
 		return errors.NewLintRuleError(
@@ -187,13 +209,11 @@ func containerImagePullPolicyIfNotPresent(object storage.StoreObject, containers
 	return nil
 }
 
-func containerStorageEphemeral(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerStorageEphemeral(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
-		}
-		if containers[i].Resources.Requests.StorageEphemeral() == nil ||
-			containers[i].Resources.Requests.StorageEphemeral().Value() == 0 {
+		if (containers[i].Resources.Requests.StorageEphemeral() == nil ||
+			containers[i].Resources.Requests.StorageEphemeral().Value() == 0) &&
+			!c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
 			return errors.NewLintRuleError(
 				ID,
 				object.Identity()+"; container = "+containers[i].Name,
@@ -206,12 +226,9 @@ func containerStorageEphemeral(object storage.StoreObject, containers []v1.Conta
 	return nil
 }
 
-func containerSecurityContext(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerSecurityContext(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
-		}
-		if containers[i].SecurityContext == nil {
+		if containers[i].SecurityContext == nil && !c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
 			return errors.NewLintRuleError(
 				ID,
 				object.Identity()+"; container = "+containers[i].Name,
@@ -224,14 +241,11 @@ func containerSecurityContext(object storage.StoreObject, containers []v1.Contai
 	return nil
 }
 
-func containerPorts(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
+func (c *Container) containerPorts(object storage.StoreObject, containers []v1.Container) *errors.LintRuleError {
 	for i := range containers {
-		if shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
-			continue
-		}
 		for _, p := range containers[i].Ports {
 			const t = 1024
-			if p.ContainerPort <= t {
+			if p.ContainerPort <= t && !c.shouldSkipModuleContainer(object.Unstructured.GetName(), containers[i].Name) {
 				return errors.NewLintRuleError(
 					ID,
 					object.Identity()+"; container = "+containers[i].Name,
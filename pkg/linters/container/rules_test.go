@@ -7,11 +7,10 @@ import (
 )
 
 func Test_shouldSkipModuleContainer(t *testing.T) {
-	Cfg = new(config.ContainerSettings)
-	Cfg.SkipContainers = []string{
+	c := New(&config.ContainerSettings{SkipContainers: []string{
 		"okmeter:okagent",
 		"d8-control-plane-manager:*image-holder",
-	}
+	}})
 	type args struct {
 		md        string
 		container string
@@ -45,7 +44,7 @@ func Test_shouldSkipModuleContainer(t *testing.T) {
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := shouldSkipModuleContainer(tt.args.md, tt.args.container); got != tt.want {
+			if got := c.shouldSkipModuleContainer(tt.args.md, tt.args.container); got != tt.want {
 				t.Errorf("shouldSkipModuleContainer() = %v, want %v", got, tt.want)
 			}
 		})
@@ -0,0 +1,130 @@
+package customrules
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/cel-go/cel"
+	"github.com/google/cel-go/ext"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Name is the name of the linter, rules are selected by "custom-rules/<rule ID>".
+const Name = "custom-rules"
+
+// CustomRules linter
+type CustomRules struct {
+	name, desc string
+	rules      []rule
+}
+
+type rule struct {
+	cfg     *config.CustomRule
+	program cel.Program
+}
+
+// New compiles expressions of the custom rules. An expression has the "object" variable with the rendered object
+// and the "module" variable with the "name" and "namespace" of the module, it must return a bool.
+func New(cfgs []config.CustomRule) (*CustomRules, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("object", cel.DynType),
+		cel.Variable("module", cel.MapType(cel.StringType, cel.StringType)),
+		ext.Strings(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &CustomRules{
+		name: Name,
+		desc: "CustomRules will check rendered objects with CEL expressions from the config",
+	}
+
+	for i := range cfgs {
+		cfg := &cfgs[i]
+
+		ast, iss := env.Compile(cfg.Expression)
+		if iss.Err() != nil {
+			return nil, fmt.Errorf("custom rule %s: %w", cfg.ID, iss.Err())
+		}
+		if t := ast.OutputType(); !t.IsExactType(cel.BoolType) && !t.IsExactType(cel.DynType) {
+			return nil, fmt.Errorf("custom rule %s: the expression returns %s instead of bool", cfg.ID, t)
+		}
+
+		program, err := env.Program(ast)
+		if err != nil {
+			return nil, fmt.Errorf("custom rule %s: %w", cfg.ID, err)
+		}
+
+		o.rules = append(o.rules, rule{cfg: cfg, program: program})
+	}
+
+	return o, nil
+}
+
+func (o *CustomRules) Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
+	result := errors.LintRuleErrorsList{}
+
+	vars := map[string]any{
+		"module": map[string]string{"name": m.GetName(), "namespace": m.GetNamespace()},
+	}
+
+	for _, object := range m.GetStorage() {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		vars["object"] = object.Unstructured.Object
+
+		for _, r := range o.rules {
+			if !linters.IsRuleEnabled(ctx, r.cfg.ID) || !r.cfg.Matches(object.Unstructured.GetKind(), object.Unstructured.GetNamespace()) {
+				continue
+			}
+
+			text := r.cfg.Message
+			out, _, err := r.program.Eval(vars)
+			if err == nil {
+				valid, ok := out.Value().(bool)
+				if ok && valid {
+					continue
+				}
+				if !ok {
+					text += fmt.Sprintf(" (the expression returned %v instead of bool)", out.Value())
+				}
+			} else {
+				text += fmt.Sprintf(" (the expression failed: %s)", err)
+			}
+
+			result.Add(errors.NewLintRuleError(
+				r.cfg.ID,
+				object.Identity(),
+				m.GetName(),
+				nil,
+				"%s", text,
+			).WithRule(r.cfg.ID).WithLocation(object.Location()))
+		}
+	}
+
+	return result, nil
+}
+
+func (o *CustomRules) Name() string {
+	return o.name
+}
+
+func (o *CustomRules) Desc() string {
+	return o.desc
+}
+
+func (o *CustomRules) Rules() []linters.Rule {
+	rules := make([]linters.Rule, 0, len(o.rules))
+	for _, r := range o.rules {
+		rules = append(rules, linters.Rule{ID: r.cfg.ID, Description: r.cfg.Message, Severity: r.cfg.DefaultSeverity()})
+	}
+
+	return rules
+}
@@ -0,0 +1,102 @@
+package customrules
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/moduletest"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+const testServices = `apiVersion: v1
+kind: Service
+metadata:
+  name: unlabeled
+  namespace: d8-test
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: labeled
+  namespace: d8-test
+  labels:
+    owner: team
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: other
+  namespace: default
+`
+
+func loadRules(t *testing.T, content string) []config.CustomRule {
+	t.Helper()
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".dmtlint.yaml")
+	require.NoError(t, os.WriteFile(file, []byte(content), 0o600))
+
+	cfg, err := config.NewDefault([]string{dir}, config.LoaderOptions{Config: file})
+	require.NoError(t, err)
+
+	return cfg.CustomRules
+}
+
+func TestCustomRules_Run(t *testing.T) {
+	l, err := New(loadRules(t, `
+custom-rules:
+  - id: service-owner
+    message: Services in d8-* namespaces must have the owner label
+    severity: warning
+    match:
+      kinds: [Service]
+      namespaces: ["d8-*"]
+    expression: "has(object.metadata.labels) && 'owner' in object.metadata.labels"
+  - id: service-name
+    message: Services must not be named after the module
+    expression: object.metadata.name != module.name
+`))
+	require.NoError(t, err)
+	require.Equal(t, []linters.Rule{
+		{ID: "service-owner", Description: "Services in d8-* namespaces must have the owner label", Severity: errors.SeverityWarning},
+		{ID: "service-name", Description: "Services must not be named after the module"},
+	}, l.Rules())
+
+	result, err := l.Run(context.Background(), moduletest.New(t, map[string]string{"templates/services.yaml": testServices}))
+	require.NoError(t, err)
+	require.Len(t, result.GetErrors(), 1)
+
+	e := result.GetErrors()[0]
+	require.Equal(t, "service-owner", e.ID)
+	require.Equal(t, "service-owner", e.Rule)
+	require.Equal(t, "kind = Service ; name = unlabeled ; namespace = d8-test", e.ObjectID)
+	require.Equal(t, "test-module", e.Module)
+	require.Equal(t, "Services in d8-* namespaces must have the owner label", e.Text)
+	require.Equal(t, "templates/services.yaml", e.Location.File)
+}
+
+func TestCustomRules_evaluationError(t *testing.T) {
+	l, err := New([]config.CustomRule{{ID: "owner", Message: "Objects must have the owner label", Expression: "object.metadata.labels.owner == 'team'"}})
+	require.NoError(t, err)
+
+	result, err := l.Run(context.Background(), moduletest.New(t, map[string]string{"templates/services.yaml": testServices}))
+	require.NoError(t, err)
+	require.Len(t, result.GetErrors(), 2)
+	for _, e := range result.GetErrors() {
+		require.Contains(t, e.Text, "Objects must have the owner label (the expression failed: no such key: labels)")
+	}
+}
+
+func TestNew_invalidExpression(t *testing.T) {
+	_, err := New([]config.CustomRule{{ID: "owner", Expression: "object.metadata.name +"}})
+	require.ErrorContains(t, err, "custom rule owner")
+
+	_, err = New([]config.CustomRule{{ID: "owner", Expression: "'owner'"}})
+	require.ErrorContains(t, err, "the expression returns string instead of bool")
+}
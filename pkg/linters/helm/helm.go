@@ -1,9 +1,12 @@
 package helm
 
 import (
+	"context"
+
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 	"github.com/deckhouse/dmt/pkg/linters/helm/rules"
 )
 
@@ -14,8 +17,6 @@ type Helm struct {
 }
 
 func New(cfg *config.HelmSettings) *Helm {
-	rules.Cfg = cfg
-
 	return &Helm{
 		name: "helm",
 		desc: "Lint helm objects",
@@ -23,12 +24,12 @@ func New(cfg *config.HelmSettings) *Helm {
 	}
 }
 
-func (*Helm) Run(m *module.Module) (result errors.LintRuleErrorsList, err error) {
+func (o *Helm) Run(ctx context.Context, m *module.Module) (result errors.LintRuleErrorsList, err error) {
 	if m == nil {
 		return result, err
 	}
 
-	result.Merge(rules.ApplyHelmRules(m))
+	result.Merge(rules.ApplyHelmRules(ctx, o.cfg, m))
 
 	return result, nil
 }
@@ -40,3 +41,7 @@ func (o *Helm) Name() string {
 func (o *Helm) Desc() string {
 	return o.desc
 }
+
+func (*Helm) Rules() []linters.Rule {
+	return rules.Rules
+}
@@ -25,16 +25,13 @@ import (
 	"regexp"
 	"strings"
 
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
-func skipModuleImageNameIfNeeded(filePath string) bool {
-	for _, img := range Cfg.SkipModuleImageName {
-		if strings.HasSuffix(filePath, img) {
-			return true
-		}
-	}
-	return false
+// skipModuleImageNameIfNeeded reports whether the finding in the image file is suppressed by the settings.
+func skipModuleImageNameIfNeeded(cfg *config.HelmSettings, filePath string) bool {
+	return cfg.IsModuleImageNameSkipped(filePath).Suppress()
 }
 
 var regexPatterns = map[string]string{
@@ -61,14 +58,9 @@ var distrolessImagesPrefix = map[string][]string{
 	},
 }
 
-func skipDistrolessImageCheckIfNeeded(image string) bool {
-	for _, img := range Cfg.SkipDistrolessImageCheck {
-		if strings.HasSuffix(image, img) {
-			return true
-		}
-	}
-
-	return false
+// skipDistrolessImageCheckIfNeeded reports whether the base image finding of the image is suppressed by the settings.
+func skipDistrolessImageCheckIfNeeded(cfg *config.HelmSettings, image string) bool {
+	return cfg.IsDistrolessImageCheckSkipped(image).Suppress()
 }
 
 func imageRegexp(s string) string {
@@ -86,6 +78,7 @@ func isImageNameUnacceptable(imageName string) (b bool, s string) {
 }
 
 func CheckImageNamesInDockerAndWerfFiles(
+	cfg *config.HelmSettings,
 	name, path string,
 ) (lintRuleErrorsList errors.LintRuleErrorsList) {
 	var filePaths []string
@@ -114,20 +107,30 @@ func CheckImageNamesInDockerAndWerfFiles(
 			nil,
 			"Cannot read directory structure: %s",
 			err.Error(),
-		))
+		).WithFilePath(ImagesDir))
 		return lintRuleErrorsList
 	}
 	for _, filePath := range filePaths {
-		if skipModuleImageNameIfNeeded(filePath) {
+		lerr := lintOneDockerfileOrWerfYAML(cfg, name, filePath, imagesPath)
+		if lerr != nil && skipModuleImageNameIfNeeded(cfg, filePath) {
 			continue
 		}
-		lintRuleErrorsList.Add(lintOneDockerfileOrWerfYAML(name, filePath, imagesPath))
+		lintRuleErrorsList.Add(lerr.WithFilePath(relativeModulePath(path, filePath)))
 	}
 
 	return lintRuleErrorsList
 }
 
-func lintOneDockerfileOrWerfYAML(name, filePath, imagesPath string) *errors.LintRuleError {
+func relativeModulePath(modulePath, filePath string) string {
+	rel, err := filepath.Rel(modulePath, filePath)
+	if err != nil {
+		return filePath
+	}
+
+	return rel
+}
+
+func lintOneDockerfileOrWerfYAML(cfg *config.HelmSettings, name, filePath, imagesPath string) *errors.LintRuleError {
 	file, err := os.Open(filePath)
 	if err != nil {
 		return errors.NewLintRuleError(
@@ -157,6 +160,7 @@ func lintOneDockerfileOrWerfYAML(name, filePath, imagesPath string) *errors.Lint
 
 	var (
 		dockerfileFromInstructions []string
+		dockerfileFromPositions    []int
 		lastWerfImagePos           int
 	)
 	isWerfYAML := filepath.Base(filePath) == "werf.inc.yaml"
@@ -169,10 +173,10 @@ func lintOneDockerfileOrWerfYAML(name, filePath, imagesPath string) *errors.Lint
 			return errors.NewLintRuleError(
 				ID,
 				fmt.Sprintf("module = %s, image = %s, line = %d", name, relativeFilePath, linePos),
+				name,
 				line,
-				nil,
 				"Please use %s as an image name", ciVariable,
-			)
+			).WithPosition(linePos, 1)
 		}
 
 		if isWerfYAML {
@@ -182,21 +186,20 @@ func lintOneDockerfileOrWerfYAML(name, filePath, imagesPath string) *errors.Lint
 				fromTrimmed := strings.TrimPrefix(line, "from: ")
 				// "from:" right after "image:"
 				if linePos-lastWerfImagePos == 1 {
-					if skipDistrolessImageCheckIfNeeded(relativeFilePath) {
+					result, message := isWerfInstructionUnacceptable(fromTrimmed)
+					if result && skipDistrolessImageCheckIfNeeded(cfg, relativeFilePath) {
 						log.Printf("WARNING!!! SKIP DISTROLESS CHECK!!!\nmodule = %s, image = %s\nvalue - %s\n\n", name, relativeFilePath, fromTrimmed)
 						continue
 					}
-
-					result, message := isWerfInstructionUnacceptable(fromTrimmed)
 					if result {
 						return errors.NewLintRuleError(
 							ID,
-							name,
 							fmt.Sprintf("module = %s, image = %s", name, relativeFilePath),
-							nil,
+							name,
 							fromTrimmed,
+							"%s",
 							message,
-						)
+						).WithPosition(linePos, len("from: ")+1)
 					}
 				}
 			}
@@ -205,26 +208,26 @@ func lintOneDockerfileOrWerfYAML(name, filePath, imagesPath string) *errors.Lint
 		if strings.HasPrefix(line, "FROM ") {
 			fromTrimmed := strings.TrimPrefix(line, "FROM ")
 			dockerfileFromInstructions = append(dockerfileFromInstructions, fromTrimmed)
+			dockerfileFromPositions = append(dockerfileFromPositions, linePos)
 		}
 	}
 
 	for i, fromInstruction := range dockerfileFromInstructions {
 		lastInstruction := i == len(dockerfileFromInstructions)-1
-		if skipDistrolessImageCheckIfNeeded(relativeFilePath) {
+		result, message := isDockerfileInstructionUnacceptable(fromInstruction, lastInstruction)
+		if result && skipDistrolessImageCheckIfNeeded(cfg, relativeFilePath) {
 			log.Printf("WARNING!!! SKIP DISTROLESS CHECK!!!\nmodule = %s, image = %s\nvalue - %s\n\n", name, relativeFilePath, fromInstruction)
 			continue
 		}
-
-		result, message := isDockerfileInstructionUnacceptable(fromInstruction, lastInstruction)
 		if result {
 			return errors.NewLintRuleError(
 				ID,
-				name,
-				name,
 				fmt.Sprintf("module = %s, image = %s", name, relativeFilePath),
+				name,
 				fromInstruction,
+				"%s",
 				message,
-			)
+			).WithPosition(dockerfileFromPositions[i], len("FROM ")+1)
 		}
 	}
 
@@ -1,10 +1,10 @@
 package rules
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
-	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
@@ -12,6 +12,7 @@ import (
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 const (
@@ -28,16 +29,30 @@ const (
 	ID = "helm"
 )
 
-var Cfg *config.HelmSettings
+// Rule IDs of the linter.
+const (
+	HelmignoreRule = "helmignore"
+	ImagesRule     = "images"
+	ChartRule      = "chart"
+	NamespaceRule  = "namespace"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: HelmignoreRule, Description: "Module must have .helmignore with module directories which are not helm templates"},
+	{ID: ImagesRule, Description: "Dockerfiles and werf.inc.yaml must use allowed base images and image name variables"},
+	{ID: ChartRule, Description: "Module must have a valid Chart.yaml and values"},
+	{ID: NamespaceRule, Description: "Module must have a .namespace file"},
+}
 
 var toHelmignore = []string{HooksDir, openapiDir, CrdsDir, ImagesDir, "enabled"}
 
-func namespaceModuleRule(name, path string) (string, *errors.LintRuleError) {
-	if slices.Contains(Cfg.SkipNamespaceCheck, name) {
-		return "", nil
-	}
+func namespaceModuleRule(cfg *config.HelmSettings, name, path string) (string, *errors.LintRuleError) {
 	content, err := os.ReadFile(filepath.Join(path, ".namespace"))
 	if err != nil {
+		if cfg.IsNamespaceCheckSkipped(name).Suppress() {
+			return "", nil
+		}
 		return "", errors.NewLintRuleError(
 			ID,
 			name,
@@ -86,11 +101,16 @@ func chartModuleRule(name, path string) (string, *errors.LintRuleError) {
 	return chart.Name, nil
 }
 
-func helmignoreModuleRule(name, path string) *errors.LintRuleError {
-	if slices.Contains(Cfg.SkipHelmIgnoreCheck, name) {
+func helmignoreModuleRule(cfg *config.HelmSettings, name, path string) *errors.LintRuleError {
+	lerr := helmignoreRule(name, path)
+	if lerr != nil && cfg.IsHelmIgnoreCheckSkipped(name).Suppress() {
 		return nil
 	}
 
+	return lerr
+}
+
+func helmignoreRule(name, path string) *errors.LintRuleError {
 	var existedFiles []string
 	for _, file := range toHelmignore {
 		if IsExistsOnFilesystem(path, file) {
@@ -139,22 +159,29 @@ func IsExistsOnFilesystem(parts ...string) bool {
 	return err == nil
 }
 
-func ApplyHelmRules(m *module.Module) (result errors.LintRuleErrorsList) {
-	result.Add(helmignoreModuleRule(m.GetName(), m.GetPath()))
-	result.Merge(CheckImageNamesInDockerAndWerfFiles(m.GetName(), m.GetPath()))
+func ApplyHelmRules(ctx context.Context, cfg *config.HelmSettings, m *module.Module) (result errors.LintRuleErrorsList) {
+	if linters.IsRuleEnabled(ctx, HelmignoreRule) {
+		result.Add(helmignoreModuleRule(cfg, m.GetName(), m.GetPath()).WithRule(HelmignoreRule).WithFilePath(".helmignore"))
+	}
 
-	name, lintError := chartModuleRule(m.GetName(), m.GetPath())
-	result.Add(lintError)
-	if name == "" {
-		return result
+	if linters.IsRuleEnabled(ctx, ImagesRule) {
+		images := CheckImageNamesInDockerAndWerfFiles(cfg, m.GetName(), m.GetPath())
+		images.SetRule(ImagesRule)
+		result.Merge(images)
 	}
 
-	namespace, lintError := namespaceModuleRule(m.GetName(), m.GetPath())
-	result.Add(lintError)
-	if namespace == "" {
+	// the namespace is checked only in charts with a name, so the chart is read even if its rule is disabled
+	name, lintError := chartModuleRule(m.GetName(), m.GetPath())
+	if linters.IsRuleEnabled(ctx, ChartRule) {
+		result.Add(lintError.WithRule(ChartRule).WithFilePath(ChartConfigFilename))
+	}
+	if name == "" || !linters.IsRuleEnabled(ctx, NamespaceRule) {
 		return result
 	}
 
+	_, lintError = namespaceModuleRule(cfg, m.GetName(), m.GetPath())
+	result.Add(lintError.WithRule(NamespaceRule).WithFilePath(".namespace"))
+
 	return result
 }
 
@@ -40,11 +40,17 @@ func shouldSkipCrd(name string) bool {
 
 func CrdsModuleRule(name, path string) errors.LintRuleErrorsList {
 	var lintRuleErrorsList errors.LintRuleErrorsList
+	modulePath := filepath.Dir(path)
 	_ = filepath.Walk(path, func(path string, _ os.FileInfo, _ error) error {
 		if filepath.Ext(path) != ".yaml" {
 			return nil
 		}
 
+		relPath, err := filepath.Rel(modulePath, path)
+		if err != nil {
+			relPath = path
+		}
+
 		fileContent, err := os.ReadFile(path)
 		if err != nil {
 			return err
@@ -65,9 +71,10 @@ func CrdsModuleRule(name, path string) errors.LintRuleErrorsList {
 				lintRuleErrorsList.Add(errors.NewLintRuleError(
 					rules.ID,
 					"module = "+name,
+					name,
 					err.Error(),
 					"Can't parse manifests in %s folder", rules.CrdsDir,
-				))
+				).WithFilePath(relPath))
 			}
 
 			if shouldSkipCrd(crd.Name) {
@@ -77,11 +84,11 @@ func CrdsModuleRule(name, path string) errors.LintRuleErrorsList {
 			if crd.APIVersion != "apiextensions.k8s.io/v1" {
 				lintRuleErrorsList.Add(errors.NewLintRuleError(
 					rules.ID,
-					d,
 					fmt.Sprintf("kind = %s ; name = %s ; module = %s ; file = %s", crd.Kind, crd.Name, name, path),
+					name,
 					crd.APIVersion,
 					`CRD specified using deprecated api version, wanted "apiextensions.k8s.io/v1"`,
-				))
+				).WithFilePath(relPath))
 			}
 		}
 		return nil
@@ -18,16 +18,17 @@ package pdb
 
 import (
 	"fmt"
-	"slices"
 
 	appsv1 "k8s.io/api/apps/v1"
 	policyv1 "k8s.io/api/policy/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 	"github.com/deckhouse/dmt/pkg/linters/k8s-resources/vpa"
 )
@@ -41,19 +42,13 @@ const (
 	ID = "pdb"
 )
 
-var SkipPDBChecks []string
-
 func (s *nsLabelSelector) Matches(namespace string, labelSet labels.Set) bool {
 	return s.namespace == namespace && s.selector.Matches(labelSet)
 }
 
 // ControllerMustHavePDB adds linting errors if there are pods from controllers which are not covered (except DaemonSets)
 // by a PodDisruptionBudget
-func ControllerMustHavePDB(md *module.Module) (result errors.LintRuleErrorsList) {
-	if slices.Contains(SkipPDBChecks, md.GetNamespace()+":"+md.GetName()) {
-		return errors.LintRuleErrorsList{}
-	}
-
+func ControllerMustHavePDB(cfg *config.K8SResourcesSettings, md *module.Module) (result errors.LintRuleErrorsList) {
 	pdbSelectors, lerr := collectPDBSelectors(md)
 	result.Merge(lerr)
 
@@ -67,23 +62,69 @@ func ControllerMustHavePDB(md *module.Module) (result errors.LintRuleErrorsList)
 		}
 
 		lerr := ensurePDBIsPresent(md, pdbSelectors, object)
-		result.Add(lerr)
+		result.Add(lerr.WithLocation(object.Location()))
+	}
+
+	if result.Len() > 0 && cfg.IsPDBCheckSkipped(md.GetNamespace()+":"+md.GetName()).Suppress() {
+		return errors.LintRuleErrorsList{}
 	}
 
 	return result
 }
 
+// ControllersWithoutPDB returns pod controllers of the module (except DaemonSets) which pods are not covered
+// by any PodDisruptionBudget
+func ControllersWithoutPDB(md *module.Module) []storage.StoreObject {
+	pdbSelectors, _ := collectPDBSelectors(md)
+
+	var result []storage.StoreObject
+	for _, object := range md.GetObjectStore().Storage {
+		if !vpa.IsPodController(object.Unstructured.GetKind()) || isPodControllerDaemonSet(object.Unstructured.GetKind()) {
+			continue
+		}
+
+		if ensurePDBIsPresent(md, pdbSelectors, object) != nil {
+			result = append(result, object)
+		}
+	}
+
+	return result
+}
+
+// PodSelector returns labels to select pods of the controller: labels from the controller selector
+// which pods have, or all pod labels if there are no such labels
+func PodSelector(object storage.StoreObject) (map[string]string, error) {
+	podLabels, err := parsePodControllerLabels(object)
+	if err != nil {
+		return nil, err
+	}
+
+	matchLabels, _, err := unstructured.NestedStringMap(object.Unstructured.Object, "spec", "selector", "matchLabels")
+	if err != nil {
+		return nil, err
+	}
+
+	selector := make(map[string]string)
+	for key, value := range matchLabels {
+		if podLabels[key] == value {
+			selector[key] = value
+		}
+	}
+
+	if len(selector) == 0 {
+		return podLabels, nil
+	}
+
+	return selector, nil
+}
+
 func isPodControllerDaemonSet(kind string) bool {
 	return kind == "DaemonSet"
 }
 
 // DaemonSetMustNotHavePDB adds linting errors if there are pods from DaemonSets which are covered
 // by a PodDisruptionBudget
-func DaemonSetMustNotHavePDB(md *module.Module) (result errors.LintRuleErrorsList) {
-	if slices.Contains(SkipPDBChecks, md.GetNamespace()+":"+md.GetName()) {
-		return errors.LintRuleErrorsList{}
-	}
-
+func DaemonSetMustNotHavePDB(cfg *config.K8SResourcesSettings, md *module.Module) (result errors.LintRuleErrorsList) {
 	pdbSelectors, lerr := collectPDBSelectors(md)
 	result.Merge(lerr)
 
@@ -97,7 +138,11 @@ func DaemonSetMustNotHavePDB(md *module.Module) (result errors.LintRuleErrorsLis
 		}
 
 		lerr := ensurePDBIsNotPresent(md, pdbSelectors, object)
-		result.Add(lerr)
+		result.Add(lerr.WithLocation(object.Location()))
+	}
+
+	if result.Len() > 0 && cfg.IsPDBCheckSkipped(md.GetNamespace()+":"+md.GetName()).Suppress() {
+		return errors.LintRuleErrorsList{}
 	}
 
 	return result
@@ -112,7 +157,7 @@ func collectPDBSelectors(md *module.Module) (selectors []nsLabelSelector, result
 
 		labelSelector, lerr := parsePDBSelector(md, object)
 		if lerr != nil {
-			result.Add(lerr)
+			result.Add(lerr.WithLocation(object.Location()))
 		}
 
 		sel := nsLabelSelector{
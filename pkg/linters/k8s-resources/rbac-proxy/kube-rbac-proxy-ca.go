@@ -18,16 +18,14 @@ package rbacproxy
 
 import (
 	"fmt"
-	"slices"
 
 	"github.com/deckhouse/dmt/internal/set"
 	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
-var SkipKubeRbacProxyChecks []string
-
-func NamespaceMustContainKubeRBACProxyCA(objectStore *storage.UnstructuredObjectStore) (result errors.LintRuleErrorsList) {
+func NamespaceMustContainKubeRBACProxyCA(cfg *config.K8SResourcesSettings, objectStore *storage.UnstructuredObjectStore) (result errors.LintRuleErrorsList) {
 	proxyInNamespaces := set.New()
 
 	for index := range objectStore.Storage {
@@ -36,12 +34,9 @@ func NamespaceMustContainKubeRBACProxyCA(objectStore *storage.UnstructuredObject
 		}
 	}
 
-	for index := range objectStore.Storage {
+	for index, object := range objectStore.Storage {
 		if index.Kind == "Namespace" {
-			if slices.Contains(SkipKubeRbacProxyChecks, index.Namespace) {
-				continue
-			}
-			if !proxyInNamespaces.Has(index.Name) {
+			if !proxyInNamespaces.Has(index.Name) && !cfg.IsKubeRbacProxyCheckSkipped(index.Namespace).Suppress() {
 				result.Add(errors.NewLintRuleError(
 					"kube-rbac-proxy-ca",
 					fmt.Sprintf("namespace = %s", index.Name),
@@ -49,7 +44,7 @@ func NamespaceMustContainKubeRBACProxyCA(objectStore *storage.UnstructuredObject
 					proxyInNamespaces.Slice(),
 					"All system namespaces should contain kube-rbac-proxy CA certificate."+
 						"\n\tConsider using corresponding helm_lib helper 'helm_lib_kube_rbac_proxy_ca_certificate'.",
-				))
+				).WithLocation(object.Location()))
 			}
 		}
 	}
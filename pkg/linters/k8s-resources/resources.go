@@ -1,12 +1,14 @@
 package k8sresources
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 	"github.com/deckhouse/dmt/pkg/linters/k8s-resources/pdb"
 	rbacproxy "github.com/deckhouse/dmt/pkg/linters/k8s-resources/rbac-proxy"
 	"github.com/deckhouse/dmt/pkg/linters/k8s-resources/vpa"
@@ -23,14 +25,7 @@ type Object struct {
 	cfg        *config.K8SResourcesSettings
 }
 
-var Cfg *config.K8SResourcesSettings
-
 func New(cfg *config.K8SResourcesSettings) *Object {
-	Cfg = cfg
-	pdb.SkipPDBChecks = cfg.SkipPDBChecks
-	vpa.SkipVPAChecks = cfg.SkipVPAChecks
-	rbacproxy.SkipKubeRbacProxyChecks = cfg.SkipKubeRbacProxyChecks
-
 	return &Object{
 		name: "k8s-resources",
 		desc: "Lint k8s-resources",
@@ -38,27 +33,45 @@ func New(cfg *config.K8SResourcesSettings) *Object {
 	}
 }
 
-func (*Object) Run(m *module.Module) (result errors.LintRuleErrorsList, err error) {
+func (o *Object) Run(ctx context.Context, m *module.Module) (result errors.LintRuleErrorsList, err error) {
 	if m == nil {
 		return result, err
 	}
 
-	result.Merge(rbacproxy.NamespaceMustContainKubeRBACProxyCA(m.GetObjectStore()))
-	result.Merge(vpa.ControllerMustHaveVPA(m))
-	result.Merge(pdb.ControllerMustHavePDB(m))
-	result.Merge(pdb.DaemonSetMustNotHavePDB(m))
+	moduleRules := []struct {
+		id    string
+		check func() errors.LintRuleErrorsList
+	}{
+		{KubeRBACProxyCARule, func() errors.LintRuleErrorsList {
+			return rbacproxy.NamespaceMustContainKubeRBACProxyCA(o.cfg, m.GetObjectStore())
+		}},
+		{VPARule, func() errors.LintRuleErrorsList { return vpa.ControllerMustHaveVPA(o.cfg, m) }},
+		{PDBRule, func() errors.LintRuleErrorsList { return pdb.ControllerMustHavePDB(o.cfg, m) }},
+		{DaemonSetPDBRule, func() errors.LintRuleErrorsList { return pdb.DaemonSetMustNotHavePDB(o.cfg, m) }},
+	}
+	for _, rule := range moduleRules {
+		if linters.IsRuleEnabled(ctx, rule.id) {
+			result.Merge(withRule(rule.id, rule.check()))
+		}
+	}
 
 	for _, object := range m.GetStorage() {
-		result.Merge(applyContainerRules(object))
+		result.Merge(o.applyContainerRules(ctx, object))
 	}
 
-	if isExistsOnFilesystem(m.GetPath(), CrdsDir) {
-		result.Merge(CrdsModuleRule(m.GetName(), filepath.Join(m.GetPath(), CrdsDir)))
+	if linters.IsRuleEnabled(ctx, CRDsRule) && isExistsOnFilesystem(m.GetPath(), CrdsDir) {
+		result.Merge(withRule(CRDsRule, CrdsModuleRule(m.GetName(), filepath.Join(m.GetPath(), CrdsDir))))
 	}
 
 	return result, nil
 }
 
+func withRule(rule string, list errors.LintRuleErrorsList) errors.LintRuleErrorsList {
+	list.SetRule(rule)
+
+	return list
+}
+
 func (o *Object) Name() string {
 	return o.name
 }
@@ -67,6 +80,10 @@ func (o *Object) Desc() string {
 	return o.desc
 }
 
+func (*Object) Rules() []linters.Rule {
+	return Rules
+}
+
 func isExistsOnFilesystem(parts ...string) bool {
 	_, err := os.Stat(filepath.Join(parts...))
 	return err == nil
@@ -1,8 +1,8 @@
 package k8sresources
 
 import (
+	"context"
 	"fmt"
-	"slices"
 	"strings"
 
 	appsv1 "k8s.io/api/apps/v1"
@@ -12,25 +12,76 @@ import (
 
 	"github.com/deckhouse/dmt/internal/storage"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
-func applyContainerRules(object storage.StoreObject) (result errors.LintRuleErrorsList) {
-	if slices.Contains(Cfg.SkipContainerChecks, object.Unstructured.GetName()) {
-		return errors.LintRuleErrorsList{}
-	}
+// Rule IDs of the linter.
+const (
+	RecommendedLabelsRule    = "recommended-labels"
+	NamespaceLabelsRule      = "namespace-labels"
+	APIVersionRule           = "api-version"
+	PriorityClassRule        = "priority-class"
+	DNSPolicyRule            = "dns-policy"
+	SecurityContextRule      = "security-context"
+	RevisionHistoryLimitRule = "revision-history-limit"
+	HostNetworkPortsRule     = "host-network-ports"
+	ServiceTargetPortRule    = "service-target-port"
+	KubeRBACProxyCARule      = "kube-rbac-proxy-ca"
+	VPARule                  = "vpa"
+	PDBRule                  = "pdb"
+	DaemonSetPDBRule         = "daemonset-pdb"
+	CRDsRule                 = "crds"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: RecommendedLabelsRule, Description: `Objects must have the "module" and "heritage" labels`},
+	{ID: NamespaceLabelsRule, Description: "d8- namespaces must enable the prometheus rules watcher"},
+	{ID: APIVersionRule, Description: "Objects must not use deprecated api versions"},
+	{ID: PriorityClassRule, Description: "Pod controllers must use an allowed priority class"},
+	{ID: DNSPolicyRule, Description: "Pod controllers in hostNetwork must use the ClusterFirstWithHostNet dnsPolicy"},
+	{ID: SecurityContextRule, Description: "Pods must have a security context with nobody, deckhouse or root user and group"},
+	{ID: RevisionHistoryLimitRule, Description: "Deployments must have spec.revisionHistoryLimit less or equal to 2"},
+	{ID: HostNetworkPortsRule, Description: "Container ports in hostNetwork and host ports must be in the range [4200,4299]"},
+	{ID: ServiceTargetPortRule, Description: "Service ports must use named target ports"},
+	{ID: KubeRBACProxyCARule, Description: "System namespaces must contain the kube-rbac-proxy CA certificate"},
+	{ID: VPARule, Description: "Pod controllers must have a correct VerticalPodAutoscaler"},
+	{ID: PDBRule, Description: "Pod controllers (except DaemonSets) must have a PodDisruptionBudget"},
+	{ID: DaemonSetPDBRule, Description: "DaemonSets must not have a PodDisruptionBudget"},
+	{ID: CRDsRule, Description: "Deckhouse CRDs must be valid and use apiextensions.k8s.io/v1"},
+}
+
+// objectRules are checks applied to every object of the module.
+var objectRules = []struct {
+	id    string
+	check func(object storage.StoreObject) *errors.LintRuleError
+}{
+	{RecommendedLabelsRule, objectRecommendedLabels},
+	{NamespaceLabelsRule, namespaceLabels},
+	{APIVersionRule, objectAPIVersion},
+	{PriorityClassRule, objectPriorityClass},
+	{DNSPolicyRule, objectDNSPolicy},
+	{SecurityContextRule, objectSecurityContext},
+	{RevisionHistoryLimitRule, objectRevisionHistoryLimit},
+	{HostNetworkPortsRule, objectHostNetworkPorts},
+	{ServiceTargetPortRule, objectServiceTargetPort},
+}
 
+func (o *Object) applyContainerRules(ctx context.Context, object storage.StoreObject) (result errors.LintRuleErrorsList) {
 	result = errors.LintRuleErrorsList{}
 
-	result.Add(objectRecommendedLabels(object))
-	result.Add(namespaceLabels(object))
-	result.Add(objectAPIVersion(object))
-	result.Add(objectPriorityClass(object))
-	result.Add(objectDNSPolicy(object))
-	result.Add(objectSecurityContext(object))
+	for _, rule := range objectRules {
+		if !linters.IsRuleEnabled(ctx, rule.id) {
+			continue
+		}
+		result.Add(rule.check(object).WithRule(rule.id))
+	}
+
+	if result.Len() > 0 && o.cfg.IsContainerCheckSkipped(object.Unstructured.GetName()).Suppress() {
+		return errors.LintRuleErrorsList{}
+	}
 
-	result.Add(objectRevisionHistoryLimit(object))
-	result.Add(objectHostNetworkPorts(object))
-	result.Add(objectServiceTargetPort(object))
+	result.SetLocation(object.Location())
 
 	return result
 }
@@ -86,8 +137,8 @@ func newAPIVersionError(wanted, version, objectID string) *errors.LintRuleError
 		return errors.NewLintRuleError(
 			ID,
 			objectID,
+			"",
 			version,
-			nil,
 			"Object defined using deprecated api version, wanted %q", wanted,
 		)
 	}
@@ -323,7 +374,7 @@ func objectServiceTargetPort(object storage.StoreObject) *errors.LintRuleError {
 	service := new(v1.Service)
 	err := converter.FromUnstructured(object.Unstructured.UnstructuredContent(), service)
 	if err != nil {
-		panic(err)
+		return newConvertError(object, err)
 	}
 
 	for _, port := range service.Spec.Ports {
@@ -0,0 +1,32 @@
+package k8sresources
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/storage"
+)
+
+func TestObjectServiceTargetPort(t *testing.T) {
+	service := func(ports any) storage.StoreObject {
+		return storage.StoreObject{Unstructured: unstructured.Unstructured{Object: map[string]any{
+			"apiVersion": "v1",
+			"kind":       "Service",
+			"metadata":   map[string]any{"name": "app"},
+			"spec":       map[string]any{"ports": ports},
+		}}}
+	}
+
+	require.Nil(t, objectServiceTargetPort(service([]any{map[string]any{"port": int64(80), "targetPort": "http"}})))
+
+	err := objectServiceTargetPort(service([]any{map[string]any{"port": int64(80), "targetPort": int64(8080)}}))
+	require.NotNil(t, err)
+	require.Equal(t, "Service port must use a named (non-numeric) target port", err.Text)
+
+	// an invalid service is reported instead of panicking
+	err = objectServiceTargetPort(service("http"))
+	require.NotNil(t, err)
+	require.Contains(t, err.Text, "Cannot convert object to Service")
+}
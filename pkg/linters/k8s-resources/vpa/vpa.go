@@ -18,7 +18,6 @@ package vpa
 
 import (
 	"fmt"
-	"slices"
 
 	"github.com/flant/addon-operator/sdk"
 	appsv1 "k8s.io/api/apps/v1"
@@ -28,6 +27,7 @@ import (
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/internal/set"
 	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
@@ -35,14 +35,8 @@ const (
 	ID = "vpa"
 )
 
-var SkipVPAChecks []string
-
 // ControllerMustHaveVPA fills linting error regarding VPA
-func ControllerMustHaveVPA(md *module.Module) (result errors.LintRuleErrorsList) {
-	if slices.Contains(SkipVPAChecks, md.GetNamespace()+":"+md.GetName()) {
-		return errors.LintRuleErrorsList{}
-	}
-
+func ControllerMustHaveVPA(cfg *config.K8SResourcesSettings, md *module.Module) (result errors.LintRuleErrorsList) {
 	vpaTargets, vpaTolerationGroups, vpaContainerNamesMap, vpaUpdateModes, errs := parseTargetsAndTolerationGroups(md)
 	result.Merge(errs)
 
@@ -52,26 +46,46 @@ func ControllerMustHaveVPA(md *module.Module) (result errors.LintRuleErrorsList)
 			continue
 		}
 
-		ok, errs := ensureVPAIsPresent(md, vpaTargets, index, object)
-		result.Merge(errs)
-		if !ok {
-			continue
-		}
+		result.Merge(lintController(md, object, index, vpaTargets, vpaTolerationGroups, vpaContainerNamesMap, vpaUpdateModes))
+	}
 
-		// for vpa UpdateMode Off we cannot have container resource policies in vpa object
-		if vpaUpdateModes[index] == UpdateModeOff {
-			continue
-		}
+	if result.Len() > 0 && cfg.IsVPACheckSkipped(md.GetNamespace()+":"+md.GetName()).Suppress() {
+		return errors.LintRuleErrorsList{}
+	}
 
-		ok, errs = ensureVPAContainersMatchControllerContainers(md, object, index, vpaContainerNamesMap)
-		result.Merge(errs)
-		if !ok {
-			continue
-		}
+	return result
+}
 
-		result.Merge(ensureTolerations(md, vpaTolerationGroups, index, object))
+func lintController(
+	md *module.Module,
+	object storage.StoreObject,
+	index storage.ResourceIndex,
+	vpaTargets map[storage.ResourceIndex]struct{},
+	vpaTolerationGroups map[storage.ResourceIndex]string,
+	vpaContainerNamesMap map[storage.ResourceIndex]set.Set,
+	vpaUpdateModes map[storage.ResourceIndex]UpdateMode,
+) (result errors.LintRuleErrorsList) {
+	defer result.SetLocation(object.Location())
+
+	ok, errs := ensureVPAIsPresent(md, vpaTargets, index, object)
+	result.Merge(errs)
+	if !ok {
+		return result
 	}
 
+	// for vpa UpdateMode Off we cannot have container resource policies in vpa object
+	if vpaUpdateModes[index] == UpdateModeOff {
+		return result
+	}
+
+	ok, errs = ensureVPAContainersMatchControllerContainers(md, object, index, vpaContainerNamesMap)
+	result.Merge(errs)
+	if !ok {
+		return result
+	}
+
+	result.Merge(ensureTolerations(md, vpaTolerationGroups, index, object))
+
 	return result
 }
 
@@ -112,6 +126,8 @@ func fillVPAMaps(
 	vpaUpdateModes map[storage.ResourceIndex]UpdateMode,
 	vpa storage.StoreObject,
 ) (result errors.LintRuleErrorsList) {
+	defer result.SetLocation(vpa.Location())
+
 	target, ok, errs := parseVPATargetIndex(vpa)
 	result.Merge(errs)
 	if !ok {
@@ -325,13 +341,7 @@ func ensureTolerations(
 		))
 	}
 
-	isTolerationFound := false
-	for _, toleration := range tolerations {
-		if toleration.Key == "node-role.kubernetes.io/master" || toleration.Key == "node-role.kubernetes.io/control-plane" || (toleration.Key == "" && toleration.Operator == "Exists") {
-			isTolerationFound = true
-			break
-		}
-	}
+	isTolerationFound := isMasterTolerated(tolerations)
 
 	workloadLabelValue := vpaTolerationGroups[index]
 	if isTolerationFound && workloadLabelValue != "every-node" && workloadLabelValue != "master" {
@@ -357,6 +367,55 @@ func ensureTolerations(
 	return result
 }
 
+func isMasterTolerated(tolerations []v1.Toleration) bool {
+	for _, toleration := range tolerations {
+		if toleration.Key == "node-role.kubernetes.io/master" || toleration.Key == "node-role.kubernetes.io/control-plane" || (toleration.Key == "" && toleration.Operator == "Exists") {
+			return true
+		}
+	}
+
+	return false
+}
+
+// WorkloadResourcePolicy returns the value of the "workload-resource-policy.deckhouse.io" label
+// which VPA of the controller must have, it is empty if the controller does not run on master nodes
+func WorkloadResourcePolicy(object storage.StoreObject) (string, error) {
+	tolerations, err := getTolerationsList(object)
+	if err != nil {
+		return "", err
+	}
+
+	if !isMasterTolerated(tolerations) {
+		return "", nil
+	}
+
+	for _, toleration := range tolerations {
+		if toleration.Key == "" && toleration.Operator == "Exists" {
+			return "every-node", nil
+		}
+	}
+
+	return "master", nil
+}
+
+// ControllersWithoutVPA returns pod controllers of the module which are not targeted by any VPA
+func ControllersWithoutVPA(md *module.Module) []storage.StoreObject {
+	vpaTargets, _, _, _, _ := parseTargetsAndTolerationGroups(md)
+
+	var result []storage.StoreObject
+	for index, object := range md.GetObjectStore().Storage {
+		if !IsPodController(object.Unstructured.GetKind()) {
+			continue
+		}
+
+		if _, ok := vpaTargets[index]; !ok {
+			result = append(result, object)
+		}
+	}
+
+	return result
+}
+
 // returns true if linting passed, otherwise returns false
 func ensureVPAIsPresent(
 	md *module.Module,
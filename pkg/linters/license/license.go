@@ -1,13 +1,14 @@
 package license
 
 import (
-	"slices"
+	"context"
 	"strings"
 
 	"github.com/deckhouse/dmt/internal/fsutils"
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 // Copyright linter
@@ -16,10 +17,7 @@ type Copyright struct {
 	cfg        *config.LicenseSettings
 }
 
-var Cfg *config.LicenseSettings
-
 func New(cfg *config.LicenseSettings) *Copyright {
-	Cfg = cfg
 	return &Copyright{
 		name: "license",
 		desc: "Copyright will check all files in the modules for contains copyright",
@@ -27,28 +25,31 @@ func New(cfg *config.LicenseSettings) *Copyright {
 	}
 }
 
-func (o *Copyright) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
+func (o *Copyright) Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
 	if m.GetPath() == "" {
 		return errors.LintRuleErrorsList{}, nil
 	}
+
+	var result errors.LintRuleErrorsList
+	if linters.IsRuleEnabled(ctx, OSSRule) {
+		result.Merge(OssModuleRule(o.cfg, m.GetName(), m.GetPath()))
+	}
+
+	if !linters.IsRuleEnabled(ctx, CopyrightRule) {
+		return result, nil
+	}
+
 	files, err := getFiles(m.GetPath())
 	if err != nil {
 		return errors.LintRuleErrorsList{}, err
 	}
 
-	var result errors.LintRuleErrorsList
-
-	result.Merge(OssModuleRule(m.GetName(), m.GetPath()))
-
 	for _, fileName := range files {
 		name, _ := strings.CutPrefix(fileName, m.GetPath())
 		name = m.GetName() + ":" + name
-		if slices.Contains(o.cfg.CopyrightExcludes, name) {
-			continue
-		}
 
 		ok, er := checkFileCopyright(fileName)
-		if !ok {
+		if !ok && !o.cfg.IsCopyrightExcluded(name).Suppress() {
 			path, _ := strings.CutPrefix(fileName, m.GetPath())
 			result.Add(errors.NewLintRuleError(
 				"copyright",
@@ -57,7 +58,7 @@ func (o *Copyright) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
 				er,
 				"errors in `%s` module",
 				m.GetName(),
-			))
+			).WithRule(CopyrightRule).WithLocation(errors.Location{File: strings.TrimPrefix(path, "/"), Line: 1, Column: 1}))
 		}
 	}
 
@@ -87,3 +88,7 @@ func (o *Copyright) Name() string {
 func (o *Copyright) Desc() string {
 	return o.desc
 }
+
+func (*Copyright) Rules() []linters.Rule {
+	return Rules
+}
@@ -21,20 +21,20 @@ import (
 	"net/url"
 	"os"
 	"path/filepath"
-	"slices"
 	"strings"
 
 	"sigs.k8s.io/yaml"
 
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
 const ossFilename = "oss.yaml"
 
-func OssModuleRule(name, moduleRoot string) errors.LintRuleErrorsList {
+func OssModuleRule(cfg *config.LicenseSettings, name, moduleRoot string) errors.LintRuleErrorsList {
 	lintErrors := errors.LintRuleErrorsList{}
 
-	if errs := verifyOssFile(name, moduleRoot); len(errs) > 0 {
+	if errs := verifyOssFile(moduleRoot); len(errs) > 0 && !cfg.IsOssCheckSkipped(name).Suppress() {
 		for _, err := range errs {
 			ruleErr := errors.NewLintRuleError(
 				"oss",
@@ -43,7 +43,7 @@ func OssModuleRule(name, moduleRoot string) errors.LintRuleErrorsList {
 				nil,
 				"%v",
 				ossFileErrorMessage(err),
-			)
+			).WithRule(OSSRule).WithFilePath(ossFilename)
 
 			lintErrors.Add(ruleErr)
 		}
@@ -59,11 +59,8 @@ func ossFileErrorMessage(err error) string {
 	return fmt.Sprintf("Invalid %s: %s", ossFilename, err.Error())
 }
 
-func verifyOssFile(name, moduleRoot string) []error {
-	if shouldIgnoreOssInfo(name) {
-		return nil
-	}
-
+// TODO When lintignore files will be implemented in helm, detect "oss.yaml" line in it
+func verifyOssFile(moduleRoot string) []error {
 	projects, err := readOssFile(moduleRoot)
 	if err != nil {
 		return []error{err}
@@ -150,11 +147,6 @@ func parseProjectList(b []byte) ([]ossProject, error) {
 	return projects, nil
 }
 
-// TODO When lintignore files will be implemented in helm, detect "oss.yaml" line in it
-func shouldIgnoreOssInfo(moduleName string) bool {
-	return slices.Contains(Cfg.SkipOssChecks, moduleName)
-}
-
 type ossProject struct {
 	Name        string `yaml:"name"`           // example: Dex
 	Description string `yaml:"description"`    // example: A Federated OpenID Connect Provider with pluggable connectors
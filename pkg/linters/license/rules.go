@@ -0,0 +1,17 @@
+package license
+
+import (
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Rule IDs of the linter.
+const (
+	CopyrightRule = "copyright"
+	OSSRule       = "oss"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: CopyrightRule, Description: "Files must contain the copyright header"},
+	{ID: OSSRule, Description: "Module must describe used open source projects in oss.yaml"},
+}
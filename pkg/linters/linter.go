@@ -0,0 +1,35 @@
+package linters
+
+import (
+	"context"
+
+	"helm.sh/helm/v3/pkg/chart"
+
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// Module is a linted module with its chart and rendered objects.
+type Module interface {
+	GetName() string
+	GetNamespace() string
+	// GetPath returns the directory of the module.
+	GetPath() string
+	// GetScenario returns the name of the values scenario the module is rendered with, it is empty for generated values.
+	GetScenario() string
+	GetChart() *chart.Chart
+	GetMetadata() *chart.Metadata
+	GetObjectStore() *storage.UnstructuredObjectStore
+	// GetStorage returns the rendered objects of the module.
+	GetStorage() map[storage.ResourceIndex]storage.StoreObject
+}
+
+// Linter checks modules, linters can be added by dmt.RegisterLinter.
+type Linter interface {
+	// Run lints the module, it should stop when the context is done.
+	Run(ctx context.Context, m Module) (errors.LintRuleErrorsList, error)
+	Name() string
+	Desc() string
+	// Rules returns all rules of the linter.
+	Rules() []Rule
+}
@@ -0,0 +1,37 @@
+// Package linters contains types shared by all linters.
+package linters
+
+import (
+	"context"
+
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// Rule describes a single check of a linter.
+type Rule struct {
+	// ID is the stable identifier of the rule, it is unique within the linter.
+	// Rules are enabled or disabled by "<linter>/<rule ID>" selectors.
+	ID          string
+	Description string
+	// Severity is the default severity of errors of the rule, it is SeverityError if empty.
+	Severity errors.Severity
+}
+
+// RuleFilter reports whether the rule of the linter is enabled.
+type RuleFilter func(rule string) bool
+
+type ruleFilterKey struct{}
+
+// WithRuleFilter returns a context in which linters run only the rules enabled by the filter.
+// The manager sets the filter of the config, findings of disabled rules are dropped anyway,
+// but linters should skip disabled rules, since they can be slow or fail.
+func WithRuleFilter(ctx context.Context, filter RuleFilter) context.Context {
+	return context.WithValue(ctx, ruleFilterKey{}, filter)
+}
+
+// IsRuleEnabled reports whether the rule is enabled in the context, all rules are enabled without a filter.
+func IsRuleEnabled(ctx context.Context, rule string) bool {
+	filter, ok := ctx.Value(ruleFilterKey{}).(RuleFilter)
+
+	return !ok || filter(rule)
+}
@@ -1,9 +1,12 @@
 package monitoring
 
 import (
+	"context"
+
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 // Monitoring linter
@@ -14,11 +17,7 @@ type Monitoring struct {
 
 const ID = "monitoring"
 
-var Cfg *config.MonitoringSettings
-
 func New(cfg *config.MonitoringSettings) *Monitoring {
-	Cfg = cfg
-
 	return &Monitoring{
 		name: "monitoring",
 		desc: "Lint monitoring rules",
@@ -26,16 +25,23 @@ func New(cfg *config.MonitoringSettings) *Monitoring {
 	}
 }
 
-func (*Monitoring) Run(m *module.Module) (result errors.LintRuleErrorsList, err error) {
+func (o *Monitoring) Run(ctx context.Context, m *module.Module) (result errors.LintRuleErrorsList, err error) {
 	if m == nil {
 		return result, err
 	}
 
-	result.Add(MonitoringModuleRule(m.GetName(), m.GetPath(), m.GetNamespace()))
+	if linters.IsRuleEnabled(ctx, TemplatesRule) {
+		result.Add(MonitoringModuleRule(o.cfg, m.GetName(), m.GetPath(), m.GetNamespace()).
+			WithRule(TemplatesRule).WithFilePath(MonitoringTemplatePath))
+	}
+
+	if !linters.IsRuleEnabled(ctx, PrometheusRulesRule) {
+		return result, nil
+	}
 
 	// TODO: compile code instead of external binary - promtool
 	for _, object := range m.GetStorage() {
-		result.Add(PromtoolRuleCheck(m, object))
+		result.Add(PromtoolRuleCheck(m, object).WithRule(PrometheusRulesRule).WithLocation(object.Location()))
 	}
 
 	return result, nil
@@ -48,3 +54,7 @@ func (o *Monitoring) Name() string {
 func (o *Monitoring) Desc() string {
 	return o.desc
 }
+
+func (*Monitoring) Rules() []linters.Rule {
+	return Rules
+}
@@ -20,12 +20,25 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
-	"slices"
 	"strings"
 
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
+// Rule IDs of the linter.
+const (
+	TemplatesRule       = "templates"
+	PrometheusRulesRule = "prometheus-rules"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: TemplatesRule, Description: "Module with the monitoring folder must include helm_lib monitoring helpers in templates/monitoring.yaml"},
+	{ID: PrometheusRulesRule, Description: "PrometheusRules must pass promtool checks"},
+}
+
 func dirExists(moduleName, modulePath string, path ...string) (bool, *errors.LintRuleError) {
 	searchPath := filepath.Join(append([]string{modulePath}, path...)...)
 	info, err := os.Stat(searchPath)
@@ -44,11 +57,19 @@ func dirExists(moduleName, modulePath string, path ...string) (bool, *errors.Lin
 	return info.IsDir(), nil
 }
 
-func MonitoringModuleRule(moduleName, modulePath, moduleNamespace string) *errors.LintRuleError {
-	if slices.Contains(Cfg.SkipModuleChecks, moduleName) {
+// MonitoringTemplatePath is the path of the template that must include monitoring helpers, relative to the module root.
+var MonitoringTemplatePath = filepath.Join("templates", "monitoring.yaml")
+
+func MonitoringModuleRule(cfg *config.MonitoringSettings, moduleName, modulePath, moduleNamespace string) *errors.LintRuleError {
+	lerr := monitoringModuleRule(moduleName, modulePath, moduleNamespace)
+	if lerr != nil && cfg.IsModuleCheckSkipped(moduleName).Suppress() {
 		return nil
 	}
 
+	return lerr
+}
+
+func monitoringModuleRule(moduleName, modulePath, moduleNamespace string) *errors.LintRuleError {
 	folderEx, lerr := dirExists(moduleName, modulePath, "monitoring")
 	if lerr != nil {
 		return lerr
@@ -68,7 +89,7 @@ func MonitoringModuleRule(moduleName, modulePath, moduleNamespace string) *error
 		return lerr
 	}
 
-	searchingFilePath := filepath.Join(modulePath, "templates", "monitoring.yaml")
+	searchingFilePath := filepath.Join(modulePath, MonitoringTemplatePath)
 	info, _ := os.Stat(searchingFilePath)
 	if info == nil {
 		return errors.NewLintRuleError(
@@ -3,6 +3,7 @@ package nocyrillic
 import (
 	"regexp"
 	"strings"
+	"unicode/utf8"
 )
 
 var cyrRe = regexp.MustCompile(`[А-Яа-яЁё]+`)
@@ -51,6 +52,17 @@ func checkCyrillicLettersInArray(lines []string) (string, bool) {
 	return strings.Join(res, "\n"), hasCyr
 }
 
+// firstCyrillicPosition returns the line and the column (starting from 1) of the first Cyrillic letter in lines.
+func firstCyrillicPosition(lines []string) (line, column int) {
+	for i, l := range lines {
+		if loc := cyrPointerRe.FindStringIndex(l); loc != nil {
+			return i + 1, utf8.RuneCountInString(l[:loc[0]]) + 1
+		}
+	}
+
+	return 0, 0
+}
+
 func addPrefix(lines []string, prefix string) string {
 	var builder strings.Builder
 	for _, line := range lines {
@@ -51,3 +51,17 @@ func Test_found_msg(t *testing.T) {
 	require.True(t, has, "Should detect cyrillic letters in string")
 	require.Equal(t, expected, actual)
 }
+
+func Test_firstCyrillicPosition(t *testing.T) {
+	line, column := firstCyrillicPosition([]string{"foo", "ёfoo", "bar"})
+	require.Equal(t, 2, line)
+	require.Equal(t, 1, column)
+
+	line, column = firstCyrillicPosition([]string{"foo", "  — fooБ"})
+	require.Equal(t, 2, line)
+	require.Equal(t, 8, column)
+
+	line, column = firstCyrillicPosition([]string{"foo"})
+	require.Equal(t, 0, line)
+	require.Equal(t, 0, column)
+}
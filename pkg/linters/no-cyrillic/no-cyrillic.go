@@ -1,6 +1,7 @@
 package nocyrillic
 
 import (
+	"context"
 	"os"
 	"regexp"
 	"slices"
@@ -10,6 +11,7 @@ import (
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 // NoCyrillic linter
@@ -53,7 +55,7 @@ func New(cfg *config.NoCyrillicSettings) *NoCyrillic {
 	}
 }
 
-func (o *NoCyrillic) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
+func (o *NoCyrillic) Run(_ context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
 	if m.GetPath() == "" {
 		return errors.LintRuleErrorsList{}, nil
 	}
@@ -66,9 +68,6 @@ func (o *NoCyrillic) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
 	for _, fileName := range files {
 		name, _ := strings.CutPrefix(fileName, m.GetPath())
 		name = m.GetName() + ":" + name
-		if slices.Contains(o.cfg.NoCyrillicFileExcludes, name) {
-			continue
-		}
 		if o.skipDocRe.MatchString(fileName) {
 			continue
 		}
@@ -88,7 +87,7 @@ func (o *NoCyrillic) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
 
 		cyrMsg, hasCyr := checkCyrillicLettersInArray(lines)
 		fName, _ := strings.CutPrefix(fileName, m.GetPath())
-		if hasCyr {
+		if hasCyr && !o.cfg.IsFileExcluded(name).Suppress() {
 			result.Add(errors.NewLintRuleError(
 				"no-cyrillic",
 				fName,
@@ -96,7 +95,7 @@ func (o *NoCyrillic) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
 				addPrefix(strings.Split(cyrMsg, "\n"), "\t"),
 				"errors in `%s` module",
 				m.GetName(),
-			))
+			).WithRule(CyrillicLettersRule).WithFilePath(strings.TrimPrefix(fName, "/")).WithPosition(firstCyrillicPosition(lines)))
 		}
 	}
 
@@ -138,3 +137,7 @@ func (o *NoCyrillic) Name() string {
 func (o *NoCyrillic) Desc() string {
 	return o.desc
 }
+
+func (*NoCyrillic) Rules() []linters.Rule {
+	return Rules
+}
@@ -0,0 +1,15 @@
+package nocyrillic
+
+import (
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Rule IDs of the linter.
+const (
+	CyrillicLettersRule = "cyrillic-letters"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: CyrillicLettersRule, Description: "Files must not contain cyrillic letters"},
+}
@@ -1,10 +1,14 @@
 package openapi
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/hashicorp/go-multierror"
@@ -12,21 +16,36 @@ import (
 	"github.com/deckhouse/dmt/internal/fsutils"
 	"github.com/deckhouse/dmt/internal/logger"
 	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/linters"
 	"github.com/deckhouse/dmt/pkg/linters/openapi/validators"
 
 	"gopkg.in/yaml.v3"
 )
 
-const (
-	// magic number to limit count of concurrent parses. Way to avoid CPU throttling if it would be huge amount of files
-	parserConcurrentCount = 50
-)
-
 type fileValidation struct {
 	moduleName      string
 	filePath        string
 	rootPath        string
 	validationError error
+	// rule is the ID of the rule which validation failed
+	rule string
+	// line and column of the first invalid key in the file
+	line, column int
+}
+
+// keyError is a validation error of the key in the file.
+type keyError struct {
+	key  string
+	rule string
+	err  error
+}
+
+func (e *keyError) Error() string {
+	return e.err.Error()
+}
+
+func (e *keyError) Unwrap() error {
+	return e.err
 }
 
 // GetOpenAPIYAMLFiles returns all .yaml files which are placed into openapi/ | crds/ directory
@@ -69,52 +88,62 @@ func GetOpenAPIYAMLFiles(rootPath string) ([]string, error) {
 	return result, err
 }
 
-// RunOpenAPIValidator runs validator, get channel with file paths and returns channel with results
-func RunOpenAPIValidator(fileC chan fileValidation, cfg *config.OpenAPISettings) chan fileValidation {
-	resultC := make(chan fileValidation, 1)
-
-	go func() {
-		for vfile := range fileC {
-			parseResultC := make(chan error, parserConcurrentCount)
-			yamlStruct := getFileYAMLContent(filepath.Join(vfile.rootPath, vfile.filePath))
-
-			if yamlStruct == nil {
-				continue
-			}
-			runFileParser(vfile.moduleName, vfile.filePath, yamlStruct, cfg, parseResultC)
-
-			var result *multierror.Error
+// RunOpenAPIValidator validates the files and returns results by rules of files with errors. It runs in the calling
+// goroutine, so a panic of a validator is recovered by the manager.
+func RunOpenAPIValidator(ctx context.Context, files []fileValidation, cfg *config.OpenAPISettings) []fileValidation {
+	var results []fileValidation
+	for _, vfile := range files {
+		yamlStruct := getFileYAMLContent(filepath.Join(vfile.rootPath, vfile.filePath))
+		if yamlStruct == nil {
+			continue
+		}
 
-			for err := range parseResultC {
-				if err != nil {
-					result = multierror.Append(result, err)
-				}
-			}
+		resultsByRule := make(map[string]*multierror.Error)
+		for _, err := range runFileParser(ctx, vfile.moduleName, vfile.filePath, yamlStruct, cfg) {
+			rule := errorRule(err)
+			resultsByRule[rule] = multierror.Append(resultsByRule[rule], err)
+		}
 
-			resultErr := result.ErrorOrNil()
-			if resultErr == nil {
-				continue
-			}
-			resultC <- fileValidation{
+		for _, rule := range slices.Sorted(maps.Keys(resultsByRule)) {
+			result := resultsByRule[rule]
+			line, column := firstKeyErrorPosition(filepath.Join(vfile.rootPath, vfile.filePath), result.Errors)
+			results = append(results, fileValidation{
 				moduleName:      vfile.moduleName,
 				filePath:        vfile.filePath,
 				rootPath:        vfile.rootPath,
-				validationError: resultErr,
-			}
+				validationError: result.ErrorOrNil(),
+				rule:            rule,
+				line:            line,
+				column:          column,
+			})
 		}
+	}
 
-		close(resultC)
-	}()
+	return results
+}
+
+// errorRule returns the ID of the rule which produced the validation error.
+func errorRule(err error) string {
+	var kErr *keyError
+	if errors.As(err, &kErr) {
+		return kErr.rule
+	}
 
-	return resultC
+	return ID
+}
+
+// ruleValidator is a validator of the rule.
+type ruleValidator struct {
+	rule string
+	validator
 }
 
 type fileParser struct {
 	moduleName    string
 	fileName      string
-	keyValidators map[string]validator
+	keyValidators map[string]ruleValidator
 
-	resultC chan error
+	errs []error
 }
 
 func getFileYAMLContent(path string) map[any]any {
@@ -171,53 +200,55 @@ func isDeckhouseCRD(data map[any]any) bool {
 	return false
 }
 
-func (fp fileParser) parseForWrongKeys(m map[any]any, cfg *config.OpenAPISettings) {
+func (fp *fileParser) parseForWrongKeys(m map[any]any, cfg *config.OpenAPISettings) {
 	keysValidator := validators.NewKeyNameValidator(cfg)
 	err := keysValidator.Run(fp.fileName, "allfile", m)
 	if err != nil {
-		fp.resultC <- err
+		fp.errs = append(fp.errs, &keyError{rule: KeyNamesRule, err: err})
 	}
 }
 
-func runFileParser(moduleName, fileName string, data map[any]any, cfg *config.OpenAPISettings, resultC chan error) {
+// runFileParser returns validation errors of the file, validators of disabled rules are not run.
+func runFileParser(ctx context.Context, moduleName, fileName string, data map[any]any, cfg *config.OpenAPISettings) []error {
 	// exclude external CRDs
 	if isCRD(data) && !isDeckhouseCRD(data) {
-		close(resultC)
-		return
+		return nil
 	}
 
 	parser := fileParser{
 		moduleName: moduleName,
 		fileName:   fileName,
-		keyValidators: map[string]validator{
-			"enum":             validators.NewEnumValidator(cfg),
-			"highAvailability": validators.NewHAValidator(cfg),
-			"https":            validators.NewHAValidator(cfg),
+		keyValidators: map[string]ruleValidator{
+			"enum":             {EnumRule, validators.NewEnumValidator(cfg)},
+			"highAvailability": {DefaultsRule, validators.NewHAValidator(cfg)},
+			"https":            {DefaultsRule, validators.NewHAValidator(cfg)},
 		},
-		resultC: resultC,
 	}
-	if isDeckhouseCRD(data) {
+	maps.DeleteFunc(parser.keyValidators, func(_ string, val ruleValidator) bool {
+		return !linters.IsRuleEnabled(ctx, val.rule)
+	})
+	if isDeckhouseCRD(data) && linters.IsRuleEnabled(ctx, KeyNamesRule) {
 		parser.parseForWrongKeys(data, cfg)
 	}
-	go parser.startParsing(data, resultC)
+	parser.startParsing(data)
+
+	return parser.errs
 }
 
-func (fp fileParser) startParsing(m map[any]any, resultC chan error) {
+func (fp *fileParser) startParsing(m map[any]any) {
 	for k, v := range m {
-		fp.parseValue(k.(string), v)
+		fp.parseValue(fmt.Sprintf("%v", k), v)
 	}
-
-	close(resultC)
 }
 
-func (fp fileParser) parseMap(upperKey string, m map[any]any) {
+func (fp *fileParser) parseMap(upperKey string, m map[any]any) {
 	for k, v := range m {
 		absKey := fmt.Sprintf("%s.%s", upperKey, k)
 		if key, ok := k.(string); ok {
 			if val, ok := fp.keyValidators[key]; ok {
 				err := val.Run(fp.moduleName, fp.fileName, absKey, v)
 				if err != nil {
-					fp.resultC <- err
+					fp.errs = append(fp.errs, &keyError{key: absKey, rule: val.rule, err: err})
 				}
 			}
 		}
@@ -225,13 +256,13 @@ func (fp fileParser) parseMap(upperKey string, m map[any]any) {
 	}
 }
 
-func (fp fileParser) parseSlice(upperKey string, slc []any) {
+func (fp *fileParser) parseSlice(upperKey string, slc []any) {
 	for k, v := range slc {
 		fp.parseValue(fmt.Sprintf("%s[%d]", upperKey, k), v)
 	}
 }
 
-func (fp fileParser) parseValue(upperKey string, v any) {
+func (fp *fileParser) parseValue(upperKey string, v any) {
 	if v == nil {
 		return
 	}
@@ -255,6 +286,64 @@ func (fp fileParser) parseValue(upperKey string, v any) {
 	}
 }
 
+// firstKeyErrorPosition returns the position of the first key in the file which has a validation error.
+func firstKeyErrorPosition(path string, errs []error) (line, column int) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return 0, 0
+	}
+
+	var node yaml.Node
+	if err = yaml.Unmarshal(data, &node); err != nil || len(node.Content) == 0 {
+		return 0, 0
+	}
+
+	positions := make(map[string]*yaml.Node)
+	collectKeyPositions(node.Content[0], "", positions)
+
+	for _, e := range errs {
+		var kErr *keyError
+		if !errors.As(e, &kErr) {
+			continue
+		}
+
+		keyNode, ok := positions[kErr.key]
+		if !ok {
+			continue
+		}
+
+		if line == 0 || keyNode.Line < line || (keyNode.Line == line && keyNode.Column < column) {
+			line, column = keyNode.Line, keyNode.Column
+		}
+	}
+
+	return line, column
+}
+
+// collectKeyPositions fills nodes of the keys by the absolute keys in the same format that the file parser uses.
+func collectKeyPositions(node *yaml.Node, upperKey string, positions map[string]*yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			absKey := node.Content[i].Value
+			if upperKey != "" {
+				absKey = fmt.Sprintf("%s.%s", upperKey, node.Content[i].Value)
+			}
+			positions[absKey] = node.Content[i]
+			collectKeyPositions(node.Content[i+1], absKey, positions)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			collectKeyPositions(item, fmt.Sprintf("%s[%d]", upperKey, i), positions)
+		}
+	case yaml.AliasNode:
+		if node.Alias != nil {
+			collectKeyPositions(node.Alias, upperKey, positions)
+		}
+	default:
+	}
+}
+
 type validator interface {
 	Run(moduleName, fileName, absoluteKey string, value any) error
 }
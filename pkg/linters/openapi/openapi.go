@@ -1,9 +1,13 @@
 package openapi
 
 import (
+	"context"
+	"strings"
+
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 const (
@@ -24,7 +28,7 @@ func New(cfg *config.OpenAPISettings) *OpenAPI {
 	}
 }
 
-func (o *OpenAPI) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
+func (o *OpenAPI) Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
 	if m.GetPath() == "" {
 		return errors.LintRuleErrorsList{}, nil
 	}
@@ -33,20 +37,17 @@ func (o *OpenAPI) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
 		return errors.LintRuleErrorsList{}, err
 	}
 
-	filesC := make(chan fileValidation, len(apiFiles))
-	resultC := RunOpenAPIValidator(filesC, o.cfg)
-
+	files := make([]fileValidation, 0, len(apiFiles))
 	for _, apiFile := range apiFiles {
-		filesC <- fileValidation{
+		files = append(files, fileValidation{
 			moduleName: m.GetName(),
 			filePath:   apiFile,
 			rootPath:   m.GetPath(),
-		}
+		})
 	}
-	close(filesC)
 
 	var result errors.LintRuleErrorsList
-	for res := range resultC {
+	for _, res := range RunOpenAPIValidator(ctx, files, o.cfg) {
 		if res.validationError != nil {
 			result.Add(errors.NewLintRuleError(
 				ID,
@@ -55,7 +56,11 @@ func (o *OpenAPI) Run(m *module.Module) (errors.LintRuleErrorsList, error) {
 				res.validationError,
 				"errors in `%s` module",
 				m.GetName(),
-			))
+			).WithRule(res.rule).WithLocation(errors.Location{
+				File:   strings.TrimPrefix(res.filePath, "/"),
+				Line:   res.line,
+				Column: res.column,
+			}))
 		}
 	}
 
@@ -69,3 +74,7 @@ func (o *OpenAPI) Name() string {
 func (o *OpenAPI) Desc() string {
 	return o.desc
 }
+
+func (*OpenAPI) Rules() []linters.Rule {
+	return Rules
+}
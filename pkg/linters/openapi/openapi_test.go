@@ -0,0 +1,42 @@
+package openapi
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
+)
+
+func TestOpenAPI_Run(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "test-module")
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "openapi"), 0o755))
+	values := filepath.Join(dir, "openapi", "config-values.yaml")
+	require.NoError(t, os.WriteFile(values, []byte(`type: object
+properties:
+  mode:
+    type: string
+    enum: [Auto, manual]
+`), 0o600))
+	m := module.NewModuleFromStore("test-module", "d8-test-module", dir, storage.NewUnstructuredObjectStore())
+
+	linter := New(&config.OpenAPISettings{})
+	result, err := linter.Run(context.Background(), m)
+	require.NoError(t, err)
+	require.Equal(t, 1, result.Len())
+	e := result.GetErrors()[0]
+	require.Equal(t, EnumRule, e.Rule)
+	require.Equal(t, "openapi/config-values.yaml", e.Location.File)
+	require.Equal(t, 5, e.Location.Line)
+
+	// validators run in the calling goroutine, so their panics are recovered by the manager
+	require.NoError(t, os.WriteFile(values, []byte("properties:\n  mode:\n    enum: Auto\n"), 0o600))
+	require.Panics(t, func() {
+		_, _ = linter.Run(context.Background(), m)
+	})
+}
@@ -0,0 +1,19 @@
+package openapi
+
+import (
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Rule IDs of the linter.
+const (
+	EnumRule     = "enum"
+	DefaultsRule = "ha-https-defaults"
+	KeyNamesRule = "key-names"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: EnumRule, Description: "Enum values must start with a capital letter and be in CamelCase"},
+	{ID: DefaultsRule, Description: "highAvailability and https settings must have no default values"},
+	{ID: KeyNamesRule, Description: "Properties of Deckhouse CRDs must not use banned names"},
+}
@@ -16,42 +16,25 @@ var (
 )
 
 type EnumValidator struct {
-	cfg      *config.OpenAPISettings
-	key      string
-	excludes map[string]struct{}
+	cfg *config.OpenAPISettings
+	key string
 }
 
 func NewEnumValidator(cfg *config.OpenAPISettings) EnumValidator {
-	keyExcludes := make(map[string]struct{})
-
-	for _, exc := range cfg.EnumFileExcludes["*"] {
-		keyExcludes[exc+".enum"] = struct{}{}
-	}
-
 	return EnumValidator{
-		cfg:      cfg,
-		key:      "enum",
-		excludes: keyExcludes,
+		cfg: cfg,
+		key: "enum",
 	}
 }
 
 func (en EnumValidator) Run(moduleName, fileName, absoluteKey string, value any) error {
-	en.excludes = make(map[string]struct{})
-	for _, exc := range en.cfg.EnumFileExcludes[moduleName+":"+fileName] {
-		en.excludes[exc+".enum"] = struct{}{}
-	}
-	if _, ok := en.excludes[absoluteKey]; ok {
-		return nil
-	}
+	propertyKey := strings.TrimSuffix(absoluteKey, "."+en.key)
+	keys := []string{propertyKey}
 
 	// check for slice path with wildcard
-	index := arrayPathRegex.FindString(absoluteKey)
+	index := arrayPathRegex.FindString(propertyKey)
 	if index != "" {
-		wildcardKey := strings.ReplaceAll(absoluteKey, index, "*")
-		if _, ok := en.excludes[wildcardKey]; ok {
-			// excluding key with wildcard
-			return nil
-		}
+		keys = append(keys, strings.ReplaceAll(propertyKey, index, "*"))
 	}
 
 	values := value.([]any)
@@ -65,6 +48,9 @@ func (en EnumValidator) Run(moduleName, fileName, absoluteKey string, value any)
 	}
 
 	err := validateEnumValues(absoluteKey, enum)
+	if err != nil && en.cfg.IsEnumExcluded(moduleName+":"+fileName, keys...).Suppress() {
+		return nil
+	}
 
 	return err
 }
@@ -9,12 +9,12 @@ import (
 )
 
 type HAValidator struct {
-	absoluteKeysExcludes map[string]string
+	cfg *config.OpenAPISettings
 }
 
 func NewHAValidator(cfg *config.OpenAPISettings) HAValidator {
 	return HAValidator{
-		absoluteKeysExcludes: cfg.HAAbsoluteKeysExcludes,
+		cfg: cfg,
 	}
 }
 
@@ -32,7 +32,7 @@ func (ha HAValidator) Run(moduleName, file, absoluteKey string, value any) error
 
 	for key := range m {
 		if key == "default" {
-			if ha.absoluteKeysExcludes[moduleName+":"+file] == absoluteKey {
+			if ha.cfg.IsHAKeyExcluded(moduleName+":"+file, absoluteKey).Suppress() {
 				continue
 			}
 			return fmt.Errorf("%s is invalid: must have no default value", absoluteKey)
@@ -0,0 +1,246 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Name is the name of the linter, rules are selected by "policy/<package>".
+const Name = "policy"
+
+// Policy rules which produce findings, "deny" findings are errors and "warn" findings are warnings.
+// "violation" is the rule of Gatekeeper constraint templates, its findings are errors.
+const (
+	denyRule      = "deny"
+	warnRule      = "warn"
+	violationRule = "violation"
+)
+
+// findingRules are the rules which are queried in every package.
+var findingRules = []string{denyRule, warnRule, violationRule}
+
+// Policy linter
+type Policy struct {
+	name, desc string
+	packages   []pkg
+}
+
+// pkg is a Rego package with deny, warn or violation rules, it is a rule of the linter.
+type pkg struct {
+	id      string
+	queries map[string]rego.PreparedEvalQuery
+}
+
+// New loads Rego policies from the paths, "_test.rego" files are skipped.
+func New(cfg *config.PolicySettings) (*Policy, error) {
+	modules, err := loadModules(cfg.Paths)
+	if err != nil {
+		return nil, err
+	}
+
+	compiler := ast.NewCompiler()
+	if compiler.Compile(modules); compiler.Failed() {
+		return nil, fmt.Errorf("policy: %w", compiler.Errors)
+	}
+
+	o := &Policy{
+		name: Name,
+		desc: "Policy will check rendered objects with Rego policies",
+	}
+
+	// a package can be split into several files
+	paths := make([]string, 0, len(modules))
+	for _, m := range modules {
+		paths = append(paths, m.Package.Path.String())
+	}
+	slices.Sort(paths)
+
+	for _, path := range slices.Compact(paths) {
+		p := pkg{id: strings.TrimPrefix(path, "data."), queries: make(map[string]rego.PreparedEvalQuery)}
+		for _, rule := range findingRules {
+			if len(compiler.GetRulesExact(ast.MustParseRef(path+"."+rule))) == 0 {
+				continue
+			}
+
+			p.queries[rule], err = rego.New(rego.Query(path+"."+rule), rego.Compiler(compiler)).PrepareForEval(context.Background())
+			if err != nil {
+				return nil, fmt.Errorf("policy %s: %w", p.id, err)
+			}
+		}
+
+		if len(p.queries) > 0 {
+			o.packages = append(o.packages, p)
+		}
+	}
+
+	return o, nil
+}
+
+// loadModules parses Rego files in the paths, directories are walked recursively.
+func loadModules(paths []string) (map[string]*ast.Module, error) {
+	modules := make(map[string]*ast.Module)
+	for _, root := range paths {
+		err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if d.IsDir() || filepath.Ext(path) != ".rego" || strings.HasSuffix(path, "_test.rego") {
+				return nil
+			}
+
+			content, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+
+			modules[path], err = ast.ParseModule(path, string(content))
+
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("policy: %w", err)
+		}
+	}
+
+	return modules, nil
+}
+
+func (o *Policy) Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
+	result := errors.LintRuleErrorsList{}
+
+	var rs rego.ResultSet
+	for _, object := range m.GetStorage() {
+		input, err := ast.InterfaceToValue(object.Unstructured.Object)
+		if err != nil {
+			return result, fmt.Errorf("policy: %s: %w", object.Identity(), err)
+		}
+
+		review, err := ast.InterfaceToValue(admissionReview(object.Unstructured))
+		if err != nil {
+			return result, fmt.Errorf("policy: %s: %w", object.Identity(), err)
+		}
+
+		for _, p := range o.packages {
+			if !linters.IsRuleEnabled(ctx, p.id) {
+				continue
+			}
+
+			for _, rule := range findingRules {
+				query, ok := p.queries[rule]
+				if !ok {
+					continue
+				}
+
+				ruleInput := input
+				if rule == violationRule {
+					ruleInput = review
+				}
+
+				rs, err = query.Eval(ctx, rego.EvalParsedInput(ruleInput))
+				if err != nil {
+					return result, fmt.Errorf("policy %s: %w", p.id, err)
+				}
+
+				for _, msg := range messages(p.id, rs) {
+					e := errors.NewLintRuleError(
+						p.id,
+						object.Identity(),
+						m.GetName(),
+						nil,
+						"%s", msg,
+					).WithRule(p.id).WithLocation(object.Location())
+					if rule == warnRule {
+						e = e.WithSeverity(errors.SeverityWarning)
+					}
+					result.Add(e)
+				}
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// admissionReview returns the input of Gatekeeper policies, the object is reviewed as if it is created.
+// Constraint parameters are not supported, so they are empty.
+func admissionReview(object unstructured.Unstructured) map[string]any {
+	gvk := object.GroupVersionKind()
+
+	return map[string]any{
+		"review": map[string]any{
+			"kind": map[string]any{
+				"group":   gvk.Group,
+				"version": gvk.Version,
+				"kind":    gvk.Kind,
+			},
+			"name":      object.GetName(),
+			"namespace": object.GetNamespace(),
+			"operation": "CREATE",
+			"object":    object.Object,
+		},
+		"parameters": map[string]any{},
+	}
+}
+
+// messages returns messages of the rule. Partial rules return sets of strings or objects with the "msg" field,
+// complete rules return a message or true, which is reported with the default message of the package.
+func messages(id string, rs rego.ResultSet) []string {
+	var result []string
+	for _, r := range rs {
+		for _, expr := range r.Expressions {
+			values, ok := expr.Value.([]any)
+			if !ok {
+				values = []any{expr.Value}
+			}
+
+			for _, value := range values {
+				switch v := value.(type) {
+				case bool:
+					if v {
+						result = append(result, "Object violates policy "+id)
+					}
+				case string:
+					result = append(result, v)
+				case map[string]any:
+					result = append(result, fmt.Sprint(v["msg"]))
+				default:
+					result = append(result, fmt.Sprint(v))
+				}
+			}
+		}
+	}
+	slices.Sort(result)
+
+	return result
+}
+
+func (o *Policy) Name() string {
+	return o.name
+}
+
+func (o *Policy) Desc() string {
+	return o.desc
+}
+
+func (o *Policy) Rules() []linters.Rule {
+	rules := make([]linters.Rule, 0, len(o.packages))
+	for _, p := range o.packages {
+		rules = append(rules, linters.Rule{ID: p.id, Description: "Rego policy " + p.id})
+	}
+
+	return rules
+}
@@ -0,0 +1,115 @@
+package policy
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/moduletest"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+const testDeployment = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+  namespace: d8-test
+spec:
+  template:
+    spec:
+      containers:
+        - name: app
+          securityContext:
+            privileged: true
+        - name: sidecar
+`
+
+func TestPolicy_Run(t *testing.T) {
+	dir := t.TempDir()
+	moduletest.WriteFiles(t, dir, map[string]string{
+		"privileged.rego": `package kubernetes.privileged
+
+deny[msg] {
+	c := input.spec.template.spec.containers[_]
+	c.securityContext.privileged
+	msg := sprintf("Container %s must not be privileged", [c.name])
+}
+`,
+		"labels/owner.rego": `package kubernetes.labels
+
+warn[{"msg": "Objects should have the owner label"}] {
+	not input.metadata.labels.owner
+}
+`,
+		"labels/owner_test.rego": `package kubernetes.labels
+
+test_owner { not warn with input as {"metadata": {"labels": {"owner": "team"}}} }
+`,
+		"namespace.rego": `package kubernetes.namespace
+
+deny {
+	input.metadata.namespace == "default"
+}
+
+deny {
+	startswith(input.metadata.namespace, "d8-")
+	input.kind == "Deployment"
+}
+`,
+		"gatekeeper/replicas.rego": `package k8sreplicas
+
+violation[{"msg": msg, "details": {}}] {
+	input.review.kind.kind == "Deployment"
+	not input.review.object.spec.replicas
+	msg := sprintf("%s %s must set replicas", [input.review.kind.kind, input.review.name])
+}
+`,
+		"lib/helpers.rego": `package lib
+
+is_deployment { input.kind == "Deployment" }
+`,
+	})
+
+	l, err := New(&config.PolicySettings{Paths: []string{dir}})
+	require.NoError(t, err)
+	require.Equal(t, []linters.Rule{
+		{ID: "k8sreplicas", Description: "Rego policy k8sreplicas"},
+		{ID: "kubernetes.labels", Description: "Rego policy kubernetes.labels"},
+		{ID: "kubernetes.namespace", Description: "Rego policy kubernetes.namespace"},
+		{ID: "kubernetes.privileged", Description: "Rego policy kubernetes.privileged"},
+	}, l.Rules())
+
+	result, err := l.Run(context.Background(), moduletest.New(t, map[string]string{"templates/deployment.yaml": testDeployment}))
+	require.NoError(t, err)
+
+	errs := result.GetErrors()
+	require.Len(t, errs, 4)
+	require.Equal(t, "k8sreplicas", errs[0].Rule)
+	require.Equal(t, "Deployment app must set replicas", errs[0].Text)
+	require.Empty(t, errs[0].Severity)
+	require.Equal(t, "kubernetes.labels", errs[1].Rule)
+	require.Equal(t, "Objects should have the owner label", errs[1].Text)
+	require.Equal(t, errors.SeverityWarning, errs[1].Severity)
+	require.Equal(t, "kubernetes.namespace", errs[2].Rule)
+	require.Equal(t, "Object violates policy kubernetes.namespace", errs[2].Text)
+	require.Equal(t, "kubernetes.privileged", errs[3].Rule)
+	require.Equal(t, "Container app must not be privileged", errs[3].Text)
+	require.Empty(t, errs[3].Severity)
+	require.Equal(t, "kind = Deployment ; name = app ; namespace = d8-test", errs[3].ObjectID)
+	require.Equal(t, "templates/deployment.yaml", errs[3].Location.File)
+}
+
+func TestNew_errors(t *testing.T) {
+	dir := t.TempDir()
+	moduletest.WriteFiles(t, dir, map[string]string{"invalid.rego": "package invalid\n\ndeny[msg] {\n"})
+
+	_, err := New(&config.PolicySettings{Paths: []string{dir}})
+	require.ErrorContains(t, err, "invalid.rego")
+
+	_, err = New(&config.PolicySettings{Paths: []string{filepath.Join(dir, "missing")}})
+	require.ErrorContains(t, err, "no such file or directory")
+}
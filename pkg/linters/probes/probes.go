@@ -1,16 +1,16 @@
 package probes
 
 import (
-	"slices"
+	"context"
 	"strings"
 
-	"github.com/sourcegraph/conc/pool"
 	v1 "k8s.io/api/core/v1"
 
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/internal/storage"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 // Probes linter
@@ -19,10 +19,7 @@ type Probes struct {
 	cfg        *config.ProbesSettings
 }
 
-var Cfg *config.ProbesSettings
-
 func New(cfg *config.ProbesSettings) *Probes {
-	Cfg = cfg
 	return &Probes{
 		name: "probes",
 		desc: "Probes will check all containers for correct liveness and readiness probes",
@@ -30,30 +27,22 @@ func New(cfg *config.ProbesSettings) *Probes {
 	}
 }
 
-func (*Probes) Run(m *module.Module) (result errors.LintRuleErrorsList, err error) {
-	var ch = make(chan errors.LintRuleErrorsList)
-	go func() {
-		var g = pool.New().WithErrors()
-		g.Go(func() error {
-			for _, object := range m.GetStorage() {
-				containers, er := object.GetContainers()
-				if er != nil || containers == nil {
-					continue
-				}
-				ch <- containerProbes(m.GetName(), object, containers)
-			}
-
-			return nil
-		})
-		err = g.Wait()
-		close(ch)
-	}()
-
-	for er := range ch {
-		result.Merge(er)
+// Run checks containers of the module objects, it runs in the calling goroutine,
+// so a panic of a check is recovered by the manager.
+func (o *Probes) Run(ctx context.Context, m *module.Module) (result errors.LintRuleErrorsList, err error) {
+	for _, object := range m.GetStorage() {
+		if err = ctx.Err(); err != nil {
+			return result, err
+		}
+
+		containers, er := object.GetContainers()
+		if er != nil || containers == nil {
+			continue
+		}
+		result.Merge(o.containerProbes(m.GetName(), object, containers))
 	}
 
-	return result, err
+	return result, nil
 }
 
 func (o *Probes) Name() string {
@@ -64,7 +53,11 @@ func (o *Probes) Desc() string {
 	return o.desc
 }
 
-func containerProbes(
+func (*Probes) Rules() []linters.Rule {
+	return Rules
+}
+
+func (o *Probes) containerProbes(
 	moduleName string,
 	object storage.StoreObject,
 	containers []v1.Container,
@@ -72,9 +65,6 @@ func containerProbes(
 	var errorList errors.LintRuleErrorsList
 	for i := range containers {
 		container := containers[i]
-		if skipCheckProbeHandler(object.Unstructured.GetNamespace(), container.Name) {
-			continue
-		}
 
 		var errStrings []string
 		// check livenessProbe exist and correct
@@ -89,14 +79,14 @@ func containerProbes(
 			errStrings = append(errStrings, "ReadinessProbe")
 		}
 
-		if len(errStrings) > 0 {
+		if len(errStrings) > 0 && !o.skipCheckProbeHandler(object.Unstructured.GetNamespace(), container.Name) {
 			errorList.Add(errors.NewLintRuleError(
 				"probes",
 				"module = "+moduleName+" ; "+object.Identity()+" ; container = "+container.Name,
 				moduleName,
 				strings.Join(errStrings, " and "),
 				"Container does not use correct probes",
-			))
+			).WithRule(ContainerProbesRule).WithLocation(object.Location()))
 		}
 	}
 
@@ -124,11 +114,6 @@ func probeHandlerIsNotValid(probe v1.ProbeHandler) bool {
 	return false
 }
 
-func skipCheckProbeHandler(namespace, container string) bool {
-	containers, ok := Cfg.ProbesExcludes[namespace]
-	if ok {
-		return slices.Contains(containers, container)
-	}
-
-	return false
+func (o *Probes) skipCheckProbeHandler(namespace, container string) bool {
+	return o.cfg.IsContainerExcluded(namespace, container).Suppress()
 }
@@ -0,0 +1,15 @@
+package probes
+
+import (
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Rule IDs of the linter.
+const (
+	ContainerProbesRule = "container-probes"
+)
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: ContainerProbesRule, Description: "Containers must have correct liveness and readiness probes"},
+}
@@ -1,38 +1,59 @@
 package rbac
 
 import (
+	"context"
+
 	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
 	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
 	"github.com/deckhouse/dmt/pkg/linters/rbac/roles"
 )
 
 // Rbac linter
 type Rbac struct {
 	name, desc string
-	Cfg        *config.RbacSettings
+	cfg        *config.RbacSettings
 }
 
 func New(cfg *config.RbacSettings) *Rbac {
-	roles.Cfg = cfg
-
 	return &Rbac{
 		name: "rbac",
 		desc: "Lint rbac objects",
-		Cfg:  cfg,
+		cfg:  cfg,
 	}
 }
 
-func (*Rbac) Run(m *module.Module) (result errors.LintRuleErrorsList, err error) {
+func (o *Rbac) Run(ctx context.Context, m *module.Module) (result errors.LintRuleErrorsList, err error) {
 	if m == nil {
 		return result, err
 	}
 
+	checks := []struct {
+		rule  string
+		check func(object storage.StoreObject) *errors.LintRuleError
+	}{
+		{roles.UserAuthzRule, func(object storage.StoreObject) *errors.LintRuleError {
+			return roles.ObjectUserAuthzClusterRolePath(m, object)
+		}},
+		{roles.PlacementRule, func(object storage.StoreObject) *errors.LintRuleError {
+			return roles.ObjectRBACPlacement(o.cfg, m, object)
+		}},
+		{roles.BindingSubjectRule, func(object storage.StoreObject) *errors.LintRuleError {
+			return roles.ObjectBindingSubjectServiceAccountCheck(o.cfg, m, object, m.GetObjectStore())
+		}},
+		{roles.WildcardsRule, func(object storage.StoreObject) *errors.LintRuleError {
+			return roles.ObjectRolesWildcard(o.cfg, object)
+		}},
+	}
+
 	for _, object := range m.GetStorage() {
-		result.Add(roles.ObjectUserAuthzClusterRolePath(m, object))
-		result.Add(roles.ObjectRBACPlacement(m, object))
-		result.Add(roles.ObjectBindingSubjectServiceAccountCheck(m, object, m.GetObjectStore()))
-		result.Add(roles.ObjectRolesWildcard(object))
+		for _, c := range checks {
+			if linters.IsRuleEnabled(ctx, c.rule) {
+				result.Add(c.check(object).WithRule(c.rule).WithLocation(object.Location()))
+			}
+		}
 	}
 
 	return result, nil
@@ -45,3 +66,7 @@ func (o *Rbac) Name() string {
 func (o *Rbac) Desc() string {
 	return o.desc
 }
+
+func (*Rbac) Rules() []linters.Rule {
+	return roles.Rules
+}
@@ -17,26 +17,35 @@ limitations under the License.
 package roles
 
 import (
-	"slices"
-
 	v1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
-//nolint:gocyclo // because
 func ObjectBindingSubjectServiceAccountCheck(
+	cfg *config.RbacSettings,
 	m *module.Module,
 	object storage.StoreObject,
 	objectStore *storage.UnstructuredObjectStore,
 ) *errors.LintRuleError {
-	if slices.Contains(Cfg.SkipModuleCheckBinding, m.GetName()) {
+	lerr := objectBindingSubjectServiceAccountCheck(m, object, objectStore)
+	if lerr != nil && cfg.IsModuleBindingCheckSkipped(m.GetName()).Suppress() {
 		return nil
 	}
 
+	return lerr
+}
+
+//nolint:gocyclo // because
+func objectBindingSubjectServiceAccountCheck(
+	m *module.Module,
+	object storage.StoreObject,
+	objectStore *storage.UnstructuredObjectStore,
+) *errors.LintRuleError {
 	converter := runtime.DefaultUnstructuredConverter
 
 	var subjects []v1.Subject
@@ -48,14 +57,14 @@ func ObjectBindingSubjectServiceAccountCheck(
 		clusterRoleBinding := new(v1.ClusterRoleBinding)
 		err := converter.FromUnstructured(object.Unstructured.UnstructuredContent(), clusterRoleBinding)
 		if err != nil {
-			panic(err)
+			return errors.NewLintRuleError(ID, object.Identity(), m.GetName(), err.Error(), "Cannot convert object to %s", objectKind)
 		}
 		subjects = clusterRoleBinding.Subjects
 	case "RoleBinding":
 		roleBinding := new(v1.RoleBinding)
 		err := converter.FromUnstructured(object.Unstructured.UnstructuredContent(), roleBinding)
 		if err != nil {
-			panic(err)
+			return errors.NewLintRuleError(ID, object.Identity(), m.GetName(), err.Error(), "Cannot convert object to %s", objectKind)
 		}
 		subjects = roleBinding.Subjects
 
@@ -89,8 +98,8 @@ func ObjectBindingSubjectServiceAccountCheck(
 			return errors.NewLintRuleError(
 				ID,
 				object.Identity(),
+				m.GetName(),
 				subject.Name,
-				nil,
 				"%s bind to the wrong ServiceAccount (doesn't exist in the store)", objectKind,
 			)
 		}
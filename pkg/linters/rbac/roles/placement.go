@@ -19,11 +19,11 @@ package roles
 import (
 	"fmt"
 	"os"
-	"slices"
 	"strings"
 
 	"github.com/deckhouse/dmt/internal/module"
 	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
@@ -50,16 +50,22 @@ func isDeckhouseSystemNamespace(actual string) bool {
 		actual == "d8-local-path-provisioner"
 }
 
-func ObjectRBACPlacement(m *module.Module, object storage.StoreObject) *errors.LintRuleError {
-	if slices.Contains(Cfg.SkipObjectCheckBinding, m.GetName()) {
+func ObjectRBACPlacement(cfg *config.RbacSettings, m *module.Module, object storage.StoreObject) *errors.LintRuleError {
+	lerr := objectRBACPlacement(m, object)
+	if lerr != nil && cfg.IsObjectBindingCheckSkipped(m.GetName()).Suppress() {
 		return nil
 	}
+
+	return lerr
+}
+
+func objectRBACPlacement(m *module.Module, object storage.StoreObject) *errors.LintRuleError {
 	if object.ShortPath() == UserAuthzClusterRolePath || strings.HasPrefix(object.ShortPath(), RBACv2Path) {
 		return nil
 	}
 
-	objectKind := object.Unstructured.GetName()
-	switch object.Unstructured.GetName() {
+	objectKind := object.Unstructured.GetKind()
+	switch objectKind {
 	case "ServiceAccount":
 		return objectRBACPlacementServiceAccount(m, object)
 	case "ClusterRole", "ClusterRoleBinding":
@@ -0,0 +1,38 @@
+package roles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/moduletest"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
+)
+
+func TestObjectRBACPlacement_kind(t *testing.T) {
+	cfg := &config.RbacSettings{}
+
+	m := moduletest.New(t, map[string]string{".namespace": "d8-test-module\n"})
+
+	object := func(kind, name string) storage.StoreObject {
+		u := unstructured.Unstructured{}
+		u.SetKind(kind)
+		u.SetName(name)
+		u.SetNamespace("d8-test-module")
+
+		return storage.StoreObject{Path: "test-module/templates/webhook/rbac-for-us.yaml", Unstructured: u}
+	}
+
+	// objects are checked by their kind, not by their name
+	require.Nil(t, ObjectRBACPlacement(cfg, m, object("ServiceAccount", "webhook")))
+
+	e := ObjectRBACPlacement(cfg, m, object("ServiceAccount", "other"))
+	require.NotNil(t, e)
+	require.Equal(t, `Name of ServiceAccount should be equal to "webhook" or "test-module-webhook"`, e.Text)
+
+	e = ObjectRBACPlacement(cfg, m, object("ConfigMap", "webhook"))
+	require.NotNil(t, e)
+	require.Equal(t, `kind ConfigMap not allowed in "templates/webhook/rbac-for-us.yaml"`, e.Text)
+}
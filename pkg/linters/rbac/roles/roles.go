@@ -1,13 +1,25 @@
 package roles
 
 import (
-	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/linters"
 )
 
 const (
 	ID = "rbac"
 )
 
-var (
-	Cfg *config.RbacSettings
+// Rule IDs of the linter.
+const (
+	UserAuthzRule      = "user-authz"
+	PlacementRule      = "placement"
+	BindingSubjectRule = "binding-subject"
+	WildcardsRule      = "wildcards"
 )
+
+// Rules contains all rules of the linter.
+var Rules = []linters.Rule{
+	{ID: UserAuthzRule, Description: "user-authz ClusterRoles must have the access level annotation and the d8:user-authz:<module>:<level> name"},
+	{ID: PlacementRule, Description: "RBAC objects must be placed in rbac-for-us.yaml or rbac-to-us.yaml and follow the naming conventions"},
+	{ID: BindingSubjectRule, Description: "Bindings must refer to existing ServiceAccounts of the module"},
+	{ID: WildcardsRule, Description: "Roles must not use wildcards"},
+}
@@ -0,0 +1,42 @@
+package roles
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
+)
+
+func TestRoles_invalidObjects(t *testing.T) {
+	cfg := &config.RbacSettings{}
+	store := storage.NewUnstructuredObjectStore()
+	m := module.NewModuleFromStore("test-module", "d8-test-module", "", store)
+
+	object := func(kind string) storage.StoreObject {
+		u := unstructured.Unstructured{Object: map[string]any{
+			"subjects": "webhook",
+			"rules":    "*",
+		}}
+		u.SetKind(kind)
+		u.SetName("webhook")
+		u.SetNamespace("d8-test-module")
+
+		return storage.StoreObject{Path: "test-module/templates/webhook/rbac-for-us.yaml", Unstructured: u}
+	}
+
+	// objects which can't be converted are reported instead of panicking
+	for _, kind := range []string{"RoleBinding", "ClusterRoleBinding"} {
+		e := ObjectBindingSubjectServiceAccountCheck(cfg, m, object(kind), store)
+		require.NotNil(t, e, kind)
+		require.Equal(t, "Cannot convert object to "+kind, e.Text)
+		require.Equal(t, "kind = "+kind+" ; name = webhook ; namespace = d8-test-module", e.ObjectID)
+	}
+
+	e := ObjectRolesWildcard(cfg, object("Role"))
+	require.NotNil(t, e)
+	require.Equal(t, "Cannot convert object to Role", e.Text)
+}
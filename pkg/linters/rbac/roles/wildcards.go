@@ -24,12 +24,13 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/deckhouse/dmt/internal/storage"
+	"github.com/deckhouse/dmt/pkg/config"
 	"github.com/deckhouse/dmt/pkg/errors"
 )
 
 // ObjectRolesWildcard is a linter for checking the presence
 // of a wildcard in a Role and ClusterRole
-func ObjectRolesWildcard(object storage.StoreObject) *errors.LintRuleError {
+func ObjectRolesWildcard(cfg *config.RbacSettings, object storage.StoreObject) *errors.LintRuleError {
 	// check only `rbac-for-us.yaml` files
 	if !strings.HasSuffix(object.ShortPath(), "rbac-for-us.yaml") {
 		return nil
@@ -39,28 +40,19 @@ func ObjectRolesWildcard(object storage.StoreObject) *errors.LintRuleError {
 	objectKind := object.Unstructured.GetKind()
 	switch objectKind {
 	case "Role", "ClusterRole":
-		return checkRoles(object)
+		return checkRoles(cfg, object)
 	default:
 		return nil
 	}
 }
 
-func checkRoles(object storage.StoreObject) *errors.LintRuleError {
-	// check rbac-proxy for skip
-	for path, rules := range Cfg.SkipCheckWildcards {
-		if strings.EqualFold(object.Path, path) {
-			if slices.Contains(rules, object.Unstructured.GetName()) {
-				return nil
-			}
-		}
-	}
-
+func checkRoles(cfg *config.RbacSettings, object storage.StoreObject) *errors.LintRuleError {
 	converter := runtime.DefaultUnstructuredConverter
 
 	role := new(k8SRbac.Role)
 	err := converter.FromUnstructured(object.Unstructured.UnstructuredContent(), role)
 	if err != nil {
-		panic(err)
+		return errors.NewLintRuleError(ID, object.Identity(), object.Path, err.Error(), "Cannot convert object to %s", object.Unstructured.GetKind())
 	}
 
 	for _, rule := range role.Rules {
@@ -75,6 +67,11 @@ func checkRoles(object storage.StoreObject) *errors.LintRuleError {
 			objs = append(objs, "verbs")
 		}
 		if len(objs) > 0 {
+			// check rbac-proxy for skip
+			if cfg.IsWildcardCheckSkipped(object.Path, object.Unstructured.GetName()).Suppress() {
+				return nil
+			}
+
 			return errors.NewLintRuleError(
 				ID,
 				object.Identity(),
@@ -0,0 +1,167 @@
+package rulepack
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/deckhouse/dmt/internal/module"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// Linter runs a rule pack on modules.
+type Linter struct {
+	pack  config.RulePack
+	name  string
+	desc  string
+	rules []linters.Rule
+}
+
+// New describes the rule pack and returns the linter which runs it.
+func New(pack config.RulePack) (*Linter, error) {
+	if pack.Command == "" {
+		return nil, fmt.Errorf("rule pack without a command")
+	}
+
+	l := &Linter{pack: pack}
+
+	resp, err := l.call(context.Background(), &Request{Action: ActionDescribe})
+	if err != nil {
+		return nil, err
+	}
+	if resp.Name == "" {
+		return nil, fmt.Errorf("rule pack %s: the name is not described", pack.Command)
+	}
+
+	l.name = resp.Name
+	l.desc = resp.Description
+	for _, rule := range resp.Rules {
+		if rule.ID == "" {
+			return nil, fmt.Errorf("rule pack %s: a rule without an ID", pack.Command)
+		}
+		if rule.Severity != "" {
+			if _, err = errors.ParseSeverity(string(rule.Severity)); err != nil {
+				return nil, fmt.Errorf("rule pack %s: rule %s: %w", pack.Command, rule.ID, err)
+			}
+		}
+		l.rules = append(l.rules, linters.Rule{ID: rule.ID, Description: rule.Description, Severity: rule.Severity})
+	}
+
+	return l, nil
+}
+
+func (l *Linter) Run(ctx context.Context, m *module.Module) (errors.LintRuleErrorsList, error) {
+	result := errors.LintRuleErrorsList{}
+
+	req := &Request{
+		Action: ActionLint,
+		Module: &Module{
+			Name:      m.GetName(),
+			Namespace: m.GetNamespace(),
+			Path:      m.GetPath(),
+		},
+		Settings: l.pack.Settings,
+	}
+	if metadata := m.GetMetadata(); metadata != nil {
+		req.Module.ChartVersion = metadata.Version
+	}
+	for _, rule := range l.rules {
+		if linters.IsRuleEnabled(ctx, rule.ID) {
+			req.Rules = append(req.Rules, rule.ID)
+		}
+	}
+
+	for _, object := range m.GetStorage() {
+		req.Objects = append(req.Objects, Object{
+			Path:     object.ShortPath(),
+			Document: object.Document,
+			Line:     object.Line,
+			Object:   object.Unstructured.Object,
+		})
+	}
+	slices.SortFunc(req.Objects, func(a, b Object) int {
+		return cmp.Or(cmp.Compare(a.Path, b.Path), cmp.Compare(a.Document, b.Document))
+	})
+
+	resp, err := l.call(ctx, req)
+	if err != nil {
+		return result, err
+	}
+
+	for _, f := range resp.Findings {
+		if !slices.ContainsFunc(l.rules, func(r linters.Rule) bool { return r.ID == f.Rule }) {
+			return result, fmt.Errorf("rule pack %s: finding of the unknown rule %q", l.name, f.Rule)
+		}
+
+		e := errors.NewLintRuleError(cmp.Or(f.ID, l.name), f.Object, m.GetName(), f.Value, "%s", f.Text).
+			WithRule(f.Rule).
+			WithLocation(errors.Location{File: f.File, Line: f.Line, Column: f.Column})
+		if f.Severity != "" {
+			severity, err := errors.ParseSeverity(string(f.Severity))
+			if err != nil {
+				return result, fmt.Errorf("rule pack %s: %w", l.name, err)
+			}
+			e = e.WithSeverity(severity)
+		}
+		result.Add(e)
+	}
+
+	return result, nil
+}
+
+// call runs the rule pack with the request and returns its response, the process is killed when the context is done.
+func (l *Linter) call(ctx context.Context, req *Request) (*Response, error) {
+	req.Version = ProtocolVersion
+
+	input, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, l.pack.Command, l.pack.Args...) //nolint:gosec // the command is set in the config
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		return nil, fmt.Errorf("rule pack %s: %s: %w%s", l.pack.Command, req.Action, err, stderrSuffix(&stderr))
+	}
+
+	resp := new(Response)
+	if err = json.Unmarshal(stdout.Bytes(), resp); err != nil {
+		return nil, fmt.Errorf("rule pack %s: %s: invalid response: %w%s", l.pack.Command, req.Action, err, stderrSuffix(&stderr))
+	}
+	if resp.Error != "" {
+		return nil, fmt.Errorf("rule pack %s: %s: %s", l.pack.Command, req.Action, resp.Error)
+	}
+
+	return resp, nil
+}
+
+func stderrSuffix(stderr *bytes.Buffer) string {
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return ": " + msg
+	}
+
+	return ""
+}
+
+func (l *Linter) Name() string {
+	return l.name
+}
+
+func (l *Linter) Desc() string {
+	return l.desc
+}
+
+func (l *Linter) Rules() []linters.Rule {
+	return l.rules
+}
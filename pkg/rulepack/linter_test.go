@@ -0,0 +1,112 @@
+package rulepack
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/deckhouse/dmt/internal/moduletest"
+	"github.com/deckhouse/dmt/pkg/config"
+	"github.com/deckhouse/dmt/pkg/errors"
+	"github.com/deckhouse/dmt/pkg/linters"
+)
+
+// testPackEnv makes the test binary run as a rule pack.
+const testPackEnv = "DMT_TEST_RULE_PACK"
+
+func TestMain(m *testing.M) {
+	if os.Getenv(testPackEnv) != "" {
+		if err := Serve(testPack, lintOwnerLabel); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		os.Exit(0)
+	}
+
+	os.Exit(m.Run())
+}
+
+var testPack = Response{
+	Name:        "acme",
+	Description: "Company checks",
+	Rules:       []Rule{{ID: "owner-label", Description: "Objects must have the owner label", Severity: errors.SeverityWarning}},
+}
+
+func lintOwnerLabel(req *Request) ([]Finding, error) {
+	label, _ := req.Settings["label"].(string)
+
+	var findings []Finding
+	for _, o := range req.Objects {
+		metadata, _ := o.Object["metadata"].(map[string]any)
+		labels, _ := metadata["labels"].(map[string]any)
+		if _, ok := labels[label]; ok {
+			continue
+		}
+
+		findings = append(findings, Finding{
+			Rule:   "owner-label",
+			Object: fmt.Sprintf("%s/%s", o.Object["kind"], metadata["name"]),
+			Text:   fmt.Sprintf("Object of the %s module must have the %q label", req.Module.Name, label),
+			File:   o.Path,
+			Line:   o.Line,
+		})
+	}
+
+	return findings, nil
+}
+
+func TestLinter(t *testing.T) {
+	t.Setenv(testPackEnv, "1")
+
+	l, err := New(config.RulePack{Command: os.Args[0], Settings: map[string]any{"label": "owner"}})
+	require.NoError(t, err)
+	require.Equal(t, "acme", l.Name())
+	require.Equal(t, "Company checks", l.Desc())
+	require.Equal(t, []linters.Rule{
+		{ID: "owner-label", Description: "Objects must have the owner label", Severity: errors.SeverityWarning},
+	}, l.Rules())
+
+	m := moduletest.New(t, map[string]string{
+		"templates/cm.yaml": "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: config\n",
+		"templates/secret.yaml": "apiVersion: v1\nkind: Secret\nmetadata:\n  name: secret\n" +
+			"  labels:\n    owner: team\n",
+	})
+
+	result, err := l.Run(context.Background(), m)
+	require.NoError(t, err)
+	require.Len(t, result.GetErrors(), 1)
+
+	e := result.GetErrors()[0]
+	require.Equal(t, "acme", e.ID)
+	require.Equal(t, "owner-label", e.Rule)
+	require.Equal(t, "ConfigMap/config", e.ObjectID)
+	require.Equal(t, "test-module", e.Module)
+	require.Equal(t, `Object of the test-module module must have the "owner" label`, e.Text)
+	require.Equal(t, errors.Location{File: "templates/cm.yaml", Line: 1}, e.Location)
+}
+
+func TestLinter_Errors(t *testing.T) {
+	_, err := New(config.RulePack{Command: filepath.Join(t.TempDir(), "missing")})
+	require.Error(t, err)
+
+	// the test binary fails without the environment variable
+	_, err = New(config.RulePack{Command: os.Args[0], Args: []string{"-test.run=^$"}})
+	require.ErrorContains(t, err, "invalid response")
+}
+
+func TestServe(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, serve(strings.NewReader(`{"version": 1, "action": "describe"}`), &out, testPack, lintOwnerLabel))
+	require.JSONEq(t, `{"name": "acme", "description": "Company checks",
+		"rules": [{"id": "owner-label", "description": "Objects must have the owner label", "severity": "warning"}]}`, out.String())
+
+	out.Reset()
+	require.NoError(t, serve(strings.NewReader(`{"version": 1, "action": "fix"}`), &out, testPack, lintOwnerLabel))
+	require.JSONEq(t, `{"error": "unknown action \"fix\""}`, out.String())
+}
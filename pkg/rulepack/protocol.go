@@ -0,0 +1,103 @@
+// Package rulepack runs external rule packs, they are executables which lint modules and speak a JSON protocol.
+//
+// A rule pack is run once for every request. The request is written to its stdin as a JSON object
+// and the pack writes a JSON response to its stdout, messages written to stderr are included in errors.
+//
+// The "describe" request asks for the name of the linter and its rules, it is sent once before linting:
+//
+//	{"version": 1, "action": "describe"}
+//	{"name": "acme", "description": "Company checks", "rules": [{"id": "owner-label", "description": "..."}]}
+//
+// The "lint" request contains a module with its rendered objects, settings of the pack from the config
+// and enabled rules of the pack:
+//
+//	{"version": 1, "action": "lint", "module": {...}, "objects": [...], "settings": {...}, "rules": ["owner-label"]}
+//	{"findings": [{"rule": "owner-label", "object": "Deployment/app", "text": "...", "file": "templates/app.yaml"}]}
+//
+// A response with a non-empty "error" fails the request. Packs written in Go can use Serve.
+package rulepack
+
+import (
+	"github.com/deckhouse/dmt/pkg/errors"
+)
+
+// ProtocolVersion is the version of the protocol sent in requests.
+const ProtocolVersion = 1
+
+// Actions of requests.
+const (
+	ActionDescribe = "describe"
+	ActionLint     = "lint"
+)
+
+// Request is a request sent to a rule pack.
+type Request struct {
+	Version int    `json:"version"`
+	Action  string `json:"action"`
+
+	// Module, Objects, Settings and Rules are set for the "lint" action.
+	Module   *Module        `json:"module,omitempty"`
+	Objects  []Object       `json:"objects,omitempty"`
+	Settings map[string]any `json:"settings,omitempty"`
+	// Rules are IDs of enabled rules, packs should not run other rules, since their findings are dropped.
+	Rules []string `json:"rules,omitempty"`
+}
+
+// Module contains metadata of the linted module.
+type Module struct {
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Path is the absolute path of the module directory.
+	Path string `json:"path"`
+	// ChartVersion is the version from Chart.yaml.
+	ChartVersion string `json:"chartVersion,omitempty"`
+}
+
+// Object is a Kubernetes object rendered from templates of the module.
+type Object struct {
+	// Path is the path of the template relative to the module root.
+	Path string `json:"path"`
+	// Document is the index of the YAML document in the rendered template, starting with 0.
+	Document int `json:"document"`
+	// Line is the line of the document in the template source, it is 0 if it is unknown.
+	Line   int            `json:"line"`
+	Object map[string]any `json:"object"`
+}
+
+// Response is a response of a rule pack.
+type Response struct {
+	// Name, Description and Rules are returned for the "describe" action.
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+	Rules       []Rule `json:"rules,omitempty"`
+
+	// Findings are returned for the "lint" action.
+	Findings []Finding `json:"findings,omitempty"`
+
+	Error string `json:"error,omitempty"`
+}
+
+// Rule describes a check of the rule pack.
+type Rule struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+	// Severity is the default severity of findings of the rule, it is "error" if empty.
+	Severity errors.Severity `json:"severity,omitempty"`
+}
+
+// Finding is an error found by the rule pack.
+type Finding struct {
+	// Rule is the ID of the rule, it must be returned by the "describe" action.
+	Rule string `json:"rule"`
+	// ID is the ID of the finding, it is the name of the rule pack if empty.
+	ID     string `json:"id,omitempty"`
+	Object string `json:"object"`
+	Value  any    `json:"value,omitempty"`
+	Text   string `json:"text"`
+	// Severity overrides the default severity of the rule.
+	Severity errors.Severity `json:"severity,omitempty"`
+	// File is the path of the file relative to the module root, Line and Column are the position in it.
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
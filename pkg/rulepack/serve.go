@@ -0,0 +1,40 @@
+package rulepack
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// LintFunc lints the module of the request.
+type LintFunc func(req *Request) ([]Finding, error)
+
+// Serve handles a request of dmt in a rule pack written in Go: it reads the request from stdin
+// and writes the description or findings returned by lint to stdout.
+func Serve(description Response, lint LintFunc) error {
+	return serve(os.Stdin, os.Stdout, description, lint)
+}
+
+func serve(r io.Reader, w io.Writer, description Response, lint LintFunc) error {
+	req := new(Request)
+	if err := json.NewDecoder(r).Decode(req); err != nil {
+		return fmt.Errorf("decode request: %w", err)
+	}
+
+	var resp Response
+	switch req.Action {
+	case ActionDescribe:
+		resp = description
+	case ActionLint:
+		findings, err := lint(req)
+		if err != nil {
+			resp.Error = err.Error()
+		}
+		resp.Findings = findings
+	default:
+		resp.Error = fmt.Sprintf("unknown action %q", req.Action)
+	}
+
+	return json.NewEncoder(w).Encode(&resp)
+}